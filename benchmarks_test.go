@@ -232,6 +232,20 @@ func BenchmarkNewOKLCH(b *testing.B) {
 	}
 }
 
+func BenchmarkNewHCT(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = NewHCT(282, 87, 50, 1.0)
+	}
+}
+
+func BenchmarkHCTToRGBA(b *testing.B) {
+	c := NewHCT(282, 87, 50, 1.0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.RGBA()
+	}
+}
+
 // Benchmark stdlib compatibility
 func BenchmarkToStdColor(b *testing.B) {
 	c := RGB(0.5, 0.6, 0.7)
@@ -248,3 +262,42 @@ func BenchmarkFromStdColor(b *testing.B) {
 		FromStdColor(stdC)
 	}
 }
+
+func BenchmarkGamutMappingCSS(b *testing.B) {
+	c := NewOKLCH(0.7, 0.35, 150, 1.0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapToGamut(c, GamutCSS)
+	}
+}
+
+func BenchmarkGamutMappingRayTrace(b *testing.B) {
+	c := NewOKLCH(0.7, 0.35, 150, 1.0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapToGamut(c, GamutRayTrace)
+	}
+}
+
+func BenchmarkGamutMappingEdgeSeeker(b *testing.B) {
+	c := NewOKLCH(0.7, 0.35, 150, 1.0)
+	GamutBoundaryOf(SRGBSpace) // Build the table outside the timed loop
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapToGamut(c, GamutEdgeSeeker)
+	}
+}
+
+func BenchmarkGamutBoundaryMaxChroma(b *testing.B) {
+	boundary := GamutBoundaryOf(SRGBSpace)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		boundary.MaxChroma(0.7, 150)
+	}
+}
+
+func BenchmarkEstimateMaxChroma(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		estimateMaxChroma(0.7, 150)
+	}
+}
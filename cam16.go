@@ -0,0 +1,266 @@
+package color
+
+import "math"
+
+// Surround describes the luminance of the area around the viewing field,
+// relative to the white, in a color appearance model.
+type Surround int
+
+const (
+	// SurroundAverage is a surround about as bright as the scene, such as a
+	// print viewed in an office or a monitor in a lit room.
+	SurroundAverage Surround = iota
+	// SurroundDim is a surround darker than the scene, such as a television
+	// in a dim living room.
+	SurroundDim
+	// SurroundDark is a black surround, such as a projector in a cinema.
+	SurroundDark
+)
+
+// factors returns F, c and Nc for the surround (CIE 159:2004, table 1).
+func (s Surround) factors() (f, c, nc float64) {
+	switch s {
+	case SurroundDim:
+		return 0.9, 0.59, 0.9
+	case SurroundDark:
+		return 0.8, 0.525, 0.8
+	default:
+		return 1.0, 0.69, 1.0
+	}
+}
+
+// ViewingConditions describe how a color is viewed, for CAM16.
+type ViewingConditions struct {
+	// White is the XYZ of the adopted white, with Y = 1. The zero value means D65.
+	White [3]float64
+
+	// AdaptingLuminance (L_A) is the luminance of the adapting field in
+	// cd/m², commonly 20% of the luminance of the white.
+	AdaptingLuminance float64
+
+	// BackgroundLuminance (Y_b) is the luminance of the background relative
+	// to the white, from 0 to 100. 20 is a typical medium gray.
+	BackgroundLuminance float64
+
+	// Surround is the luminance of the area around the viewing field.
+	Surround Surround
+
+	// Discounting assumes the observer fully adapts to the white (D = 1),
+	// as when viewing a surface color under a known illuminant.
+	Discounting bool
+}
+
+// DefaultViewingConditions are the sRGB reference viewing conditions:
+// a D65 display of 80 cd/m² in an average surround of 64 lux,
+// viewed against a 20% gray background.
+var DefaultViewingConditions = ViewingConditions{
+	White:               whiteD65,
+	AdaptingLuminance:   64 / math.Pi / 5,
+	BackgroundLuminance: 20,
+	Surround:            SurroundAverage,
+}
+
+// CAM16 represents a color by its appearance under given viewing conditions,
+// in the CIE CAM16 color appearance model (Li et al., 2017).
+//   J: lightness [0, 100]      Q: brightness
+//   C: chroma                  M: colorfulness
+//   H: hue angle [0, 360)      S: saturation
+// The same stimulus has different CAM16 values under different viewing
+// conditions, e.g. it looks lighter and more colorful in a dim surround.
+type CAM16 struct {
+	J, C, H, M, S, Q float64
+	A_               float64 // A_ is alpha to avoid conflict with method
+
+	// Conditions under which the color is viewed
+	Conditions ViewingConditions
+}
+
+// NewCAM16 creates a CAM16 color from lightness J, chroma C and hue angle h
+// under the given viewing conditions. M, S and Q are derived from them.
+func NewCAM16(j, c, h, alpha float64, vc ViewingConditions) *CAM16 {
+	p := vc.params()
+	j = math.Max(0, j)
+	c = math.Max(0, c)
+
+	q := 4 / p.c * math.Sqrt(j/100) * (p.aw + 4) * p.flRoot
+	m := c * p.flRoot
+	s := 0.0
+	if q > 0 {
+		s = 100 * math.Sqrt(m/q)
+	}
+	return &CAM16{J: j, C: c, H: NormalizeHue(h), M: m, S: s, Q: q, A_: clamp01(alpha), Conditions: vc}
+}
+
+// RGBA converts CAM16 to RGBA via XYZ.
+func (c *CAM16) RGBA() (r, g, b, a float64) {
+	return c.toXYZ().RGBA()
+}
+
+// linearRGBA implements linearColor.
+func (c *CAM16) linearRGBA() (r, g, b, a float64) {
+	return c.toXYZ().linearRGBA()
+}
+
+// Alpha implements Color.
+func (c *CAM16) Alpha() float64 {
+	return c.A_
+}
+
+// WithAlpha implements Color.
+func (c *CAM16) WithAlpha(alpha float64) Color {
+	cam := *c
+	cam.A_ = clamp01(alpha)
+	return &cam
+}
+
+// toXYZ converts CAM16 to XYZ.
+func (c *CAM16) toXYZ() *XYZ {
+	x, y, z := c.Conditions.params().toXYZ(c.J, c.C, c.H)
+	return &XYZ{X: x, Y: y, Z: z, A: c.A_}
+}
+
+// ToCAM16 converts a Color to CAM16 under the given viewing conditions.
+//
+// Example:
+//   // How a color looks on a monitor in a dark room
+//   vc := color.DefaultViewingConditions
+//   vc.Surround = color.SurroundDark
+//   cam := color.ToCAM16(c, vc)
+func ToCAM16(c Color, vc ViewingConditions) *CAM16 {
+	xyz := ToXYZ(c)
+	j, chroma, h := vc.params().fromXYZ(xyz.X, xyz.Y, xyz.Z)
+	return NewCAM16(j, chroma, h, xyz.A, vc)
+}
+
+// cam16M is the CAM16 matrix from XYZ to sharpened cone responses (M16).
+var cam16M = [9]float64{
+	0.401288, 0.650173, -0.051461,
+	-0.250268, 1.204414, 0.045854,
+	-0.002079, 0.048952, 0.953127,
+}
+
+// cam16MInv is the inverse of cam16M.
+var cam16MInv = invert3(cam16M)
+
+// cam16Params holds the values CAM16 derives from the viewing conditions.
+type cam16Params struct {
+	c, nc      float64 // surround
+	n, z       float64 // background induction
+	nbb        float64 // background and chromatic induction factors (N_bb = N_cb)
+	fl, flRoot float64 // luminance adaptation factor and its fourth root
+	dRGB       [3]float64
+	aw         float64 // achromatic response of the white
+}
+
+// params computes the CAM16 values for the viewing conditions.
+func (vc ViewingConditions) params() *cam16Params {
+	white := vc.White
+	if white == ([3]float64{}) {
+		white = whiteD65
+	}
+	xw, yw, zw := white[0]*100, white[1]*100, white[2]*100
+
+	_, c, nc := vc.Surround.factors()
+	la := vc.AdaptingLuminance
+
+	k := 1 / (5*la + 1)
+	k4 := k * k * k * k
+	fl := 0.2*k4*(5*la) + 0.1*(1-k4)*(1-k4)*math.Cbrt(5*la)
+
+	d := 1.0
+	if !vc.Discounting {
+		d = DegreeOfAdaptation(la, vc.Surround)
+	}
+
+	n := vc.BackgroundLuminance / yw
+	p := &cam16Params{
+		c:      c,
+		nc:     nc,
+		n:      n,
+		z:      1.48 + math.Sqrt(n),
+		nbb:    0.725 * math.Pow(n, -0.2),
+		fl:     fl,
+		flRoot: math.Pow(fl, 0.25),
+	}
+
+	rgbW := mulMatrix3(cam16M, xw, yw, zw)
+	for i, v := range rgbW {
+		p.dRGB[i] = d*yw/v + 1 - d
+	}
+	ra := p.adapt(p.dRGB[0] * rgbW[0])
+	ga := p.adapt(p.dRGB[1] * rgbW[1])
+	ba := p.adapt(p.dRGB[2] * rgbW[2])
+	p.aw = (2*ra + ga + 0.05*ba) * p.nbb
+	return p
+}
+
+// adapt applies the post-adaptation nonlinear response compression.
+// The 0.1 offset of CIECAM02 is left out here and in the achromatic
+// response; it cancels in a, b and A.
+func (p *cam16Params) adapt(v float64) float64 {
+	x := math.Pow(p.fl*math.Abs(v)/100, 0.42)
+	return math.Copysign(400*x/(x+27.13), v)
+}
+
+// unadapt inverts adapt.
+func (p *cam16Params) unadapt(v float64) float64 {
+	av := math.Abs(v)
+	base := math.Max(0, 27.13*av/(400-av))
+	return math.Copysign(100/p.fl*math.Pow(base, 1/0.42), v)
+}
+
+// fromXYZ computes lightness J, chroma C and hue angle h from XYZ (Y = 1 for white).
+func (p *cam16Params) fromXYZ(x, y, z float64) (j, c, h float64) {
+	rgb := mulMatrix3(cam16M, x*100, y*100, z*100)
+	ra := p.adapt(p.dRGB[0] * rgb[0])
+	ga := p.adapt(p.dRGB[1] * rgb[1])
+	ba := p.adapt(p.dRGB[2] * rgb[2])
+
+	// Opponent dimensions
+	a := ra - 12*ga/11 + ba/11
+	b := (ra + ga - 2*ba) / 9
+	h = normalizeHue(math.Atan2(b, a) * 180 / math.Pi)
+
+	achromatic := (2*ra + ga + 0.05*ba) * p.nbb
+	if achromatic <= 0 {
+		return 0, 0, h
+	}
+	j = 100 * math.Pow(achromatic/p.aw, p.c*p.z)
+
+	et := 0.25 * (math.Cos(h*math.Pi/180+2) + 3.8)
+	t := 50000.0 / 13 * p.nc * p.nbb * et * math.Hypot(a, b) / (ra + ga + 1.05*ba + 0.305)
+	c = math.Pow(t, 0.9) * math.Sqrt(j/100) * math.Pow(1.64-math.Pow(0.29, p.n), 0.73)
+	return j, c, h
+}
+
+// toXYZ computes XYZ (Y = 1 for white) from lightness J, chroma C and hue angle h.
+func (p *cam16Params) toXYZ(j, c, h float64) (x, y, z float64) {
+	if j <= 0 {
+		return 0, 0, 0
+	}
+	t := math.Pow(c/(math.Sqrt(j/100)*math.Pow(1.64-math.Pow(0.29, p.n), 0.73)), 1/0.9)
+
+	hRad := h * math.Pi / 180
+	et := 0.25 * (math.Cos(hRad+2) + 3.8)
+	achromatic := p.aw * math.Pow(j/100, 1/(p.c*p.z))
+	p2 := achromatic / p.nbb
+
+	// Solve for a and b along the hue direction
+	var a, b float64
+	if t > 0 {
+		hSin, hCos := math.Sincos(hRad)
+		gamma := 23 * (p2 + 0.305) * t / (23*50000.0/13*p.nc*p.nbb*et + 11*t*hCos + 108*t*hSin)
+		a, b = gamma*hCos, gamma*hSin
+	}
+
+	ra := (460*p2 + 451*a + 288*b) / 1403
+	ga := (460*p2 - 891*a - 261*b) / 1403
+	ba := (460*p2 - 220*a - 6300*b) / 1403
+
+	r := p.unadapt(ra) / p.dRGB[0]
+	g := p.unadapt(ga) / p.dRGB[1]
+	bl := p.unadapt(ba) / p.dRGB[2]
+
+	xyz := mulMatrix3(cam16MInv, r, g, bl)
+	return xyz[0] / 100, xyz[1] / 100, xyz[2] / 100
+}
@@ -0,0 +1,115 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCAM16Reference(t *testing.T) {
+	// Reference values from the colour-science CAM16 implementation
+	vc := ViewingConditions{
+		White:               [3]float64{0.9505, 1.0000, 1.0888},
+		AdaptingLuminance:   318.31,
+		BackgroundLuminance: 20,
+		Surround:            SurroundAverage,
+	}
+	cam := ToCAM16(NewXYZ(0.1901, 0.2000, 0.2178, 1), vc)
+
+	want := map[string][2]float64{
+		"J": {cam.J, 41.73120791},
+		"C": {cam.C, 0.10335574},
+		"h": {cam.H, 217.06795977},
+		"s": {cam.S, 2.34501507},
+		"Q": {cam.Q, 195.37170899},
+		"M": {cam.M, 0.10743677},
+	}
+	for name, v := range want {
+		if !floatNear(v[0], v[1], 1e-6) {
+			t.Errorf("%s = %.8f, want %.8f", name, v[0], v[1])
+		}
+	}
+}
+
+func TestCAM16RoundTrip(t *testing.T) {
+	conditions := []ViewingConditions{
+		DefaultViewingConditions,
+		{AdaptingLuminance: 200, BackgroundLuminance: 20, Surround: SurroundDim},
+		{White: whiteD50, AdaptingLuminance: 1000, BackgroundLuminance: 50, Surround: SurroundDark, Discounting: true},
+	}
+	colors := []Color{
+		RGB(1, 0, 0), RGB(0, 1, 0), RGB(0, 0, 1), RGB(0.2, 0.4, 0.6),
+		RGB(1, 1, 1), RGB(0.5, 0.5, 0.5), RGB(0.01, 0.02, 0.03),
+		NewSpaceColor(DisplayP3Space, []float64{0, 1, 0}, 1),
+	}
+	for _, vc := range conditions {
+		for _, c := range colors {
+			want := ToXYZ(c)
+			got := ToCAM16(c, vc).toXYZ()
+			if !floatNear(got.X, want.X, 1e-9) || !floatNear(got.Y, want.Y, 1e-9) || !floatNear(got.Z, want.Z, 1e-9) {
+				t.Errorf("round trip of %v under %+v = %v, want %v", c, vc, got, want)
+			}
+		}
+	}
+
+	black := ToCAM16(RGB(0, 0, 0), DefaultViewingConditions)
+	if black.J != 0 || black.C != 0 {
+		t.Errorf("black = J %g, C %g", black.J, black.C)
+	}
+}
+
+func TestCAM16Surround(t *testing.T) {
+	// The same stimulus looks lighter against a darker surround
+	red := RGB(0.8, 0.2, 0.2)
+	avg := ToCAM16(red, DefaultViewingConditions)
+
+	dark := DefaultViewingConditions
+	dark.Surround = SurroundDark
+	if j := ToCAM16(red, dark).J; j <= avg.J {
+		t.Errorf("J in a dark surround = %g, want more than %g", j, avg.J)
+	}
+
+	// Corresponding color: the color that looks the same in the dark surround
+	match := NewCAM16(avg.J, avg.C, avg.H, 1, dark)
+	if got := ToCAM16(match, dark); !floatNear(got.J, avg.J, 1e-4) {
+		t.Errorf("J = %g, want %g", got.J, avg.J)
+	}
+}
+
+func TestCAM16UCSSpace(t *testing.T) {
+	c := NewSpaceColor(SRGBSpace, []float64{0.2, 0.4, 0.6}, 1)
+	jab := c.ConvertTo(CAM16UCSSpace)
+	back := jab.ConvertTo(SRGBSpace).Channels()
+	for i, v := range []float64{0.2, 0.4, 0.6} {
+		if !floatNear(back[i], v, 1e-6) {
+			t.Errorf("round trip = %v", back)
+			break
+		}
+	}
+
+	// With full adaptation the white has no colorfulness
+	vc := DefaultViewingConditions
+	vc.Discounting = true
+	w := NewSpaceColor(SRGBSpace, []float64{1, 1, 1}, 1).ConvertTo(NewCAM16UCSSpace(vc)).Channels()
+	if math.Hypot(w[1], w[2]) > 1e-3 {
+		t.Errorf("white a', b' = %g, %g", w[1], w[2])
+	}
+
+	if space, ok := GetSpace("cam16-ucs"); !ok || space != CAM16UCSSpace {
+		t.Error("cam16-ucs is not registered")
+	}
+}
+
+func TestDeltaECAM16(t *testing.T) {
+	a, b := RGB(0.5, 0.2, 0.2), RGB(0.52, 0.2, 0.2)
+	if d := DeltaECAM16(a, a); d != 0 {
+		t.Errorf("DeltaECAM16(a, a) = %g", d)
+	}
+	small, large := DeltaECAM16(a, b), DeltaECAM16(a, RGB(0.2, 0.5, 0.2))
+	if small <= 0 || small >= large {
+		t.Errorf("DeltaECAM16 small = %g, large = %g", small, large)
+	}
+	// Same order of magnitude as DeltaE2000
+	if r := small / DeltaE2000(a, b); r < 0.3 || r > 3 {
+		t.Errorf("DeltaECAM16 / DeltaE2000 = %g", r)
+	}
+}
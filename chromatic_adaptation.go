@@ -1,5 +1,7 @@
 package color
 
+import "math"
+
 // Chromatic adaptation transforms for converting between different white points.
 // This is essential for accurate color conversions when source and destination
 // color spaces use different white points (e.g., D50 vs D65).
@@ -13,6 +15,10 @@ var (
 	// D50 white point (used by ProPhoto RGB, ICC LAB)
 	// Corresponds to 5000K daylight (horizon light)
 	whiteD50 = [3]float64{0.96422, 1.00000, 0.82521}
+
+	// ACES white point (x = 0.32168, y = 0.33767), used by the ACES spaces
+	// Close to, but not exactly, D60
+	whiteACES = [3]float64{0.32168 / 0.33767, 1.00000, (1 - 0.32168 - 0.33767) / 0.33767}
 )
 
 // Bradford chromatic adaptation matrix
@@ -24,11 +30,7 @@ var bradfordMatrix = [9]float64{
 }
 
 // Inverse Bradford matrix
-var bradfordMatrixInv = [9]float64{
-	0.9869929, -0.1470543, 0.1599627,
-	0.4323053, 0.5183603, 0.0492912,
-	-0.0085287, 0.0400428, 0.9684867,
-}
+var bradfordMatrixInv = invert3(bradfordMatrix)
 
 // AdaptD65ToD50 adapts XYZ values from D65 white point to D50 white point.
 // This is used when converting to color spaces that use D50 (like ProPhoto RGB).
@@ -42,34 +44,180 @@ func AdaptD50ToD65(x, y, z float64) (float64, float64, float64) {
 	return adaptWhitePoint(x, y, z, whiteD50, whiteD65)
 }
 
+// Von Kries (Hunt-Pointer-Estevez) matrix, to physiological cone responses
+var vonKriesMatrix = [9]float64{
+	0.40024, 0.70760, -0.08081,
+	-0.22630, 1.16532, 0.04570,
+	0.00000, 0.00000, 0.91822,
+}
+
+// Sharp matrix (Süsstrunk et al., 2000), spectrally sharpened cone responses
+var sharpMatrix = [9]float64{
+	1.2694, -0.0988, -0.1706,
+	-0.8364, 1.8006, 0.0357,
+	0.0297, -0.0315, 1.0018,
+}
+
+// CAT02 matrix, from CIECAM02
+var cat02Matrix = [9]float64{
+	0.7328, 0.4296, -0.1624,
+	-0.7036, 1.6975, 0.0061,
+	0.0030, 0.0136, 0.9834,
+}
+
+var (
+	vonKriesMatrixInv = invert3(vonKriesMatrix)
+	sharpMatrixInv    = invert3(sharpMatrix)
+	cat02MatrixInv    = invert3(cat02Matrix)
+)
+
+// CAT is a chromatic adaptation transform: the cone response space in which
+// colors are scaled from one white to the other (von Kries adaptation).
+type CAT int
+
+const (
+	// CATBradford is the Bradford transform, used by ICC profiles and this package.
+	CATBradford CAT = iota
+	// CATXYZScaling scales XYZ directly. It is the simplest and least accurate.
+	CATXYZScaling
+	// CATVonKries scales the Hunt-Pointer-Estevez cone responses.
+	CATVonKries
+	// CATSharp scales spectrally sharpened cone responses (Süsstrunk et al., 2000).
+	CATSharp
+	// CAT02 is the transform of CIECAM02.
+	CAT02
+	// CAT16 is the transform of CAM16, which fixes CAT02's problems with
+	// highly saturated blues and purples.
+	CAT16
+)
+
+// String returns the name of the transform.
+func (m CAT) String() string {
+	switch m {
+	case CATBradford:
+		return "bradford"
+	case CATXYZScaling:
+		return "xyz-scaling"
+	case CATVonKries:
+		return "von-kries"
+	case CATSharp:
+		return "sharp"
+	case CAT02:
+		return "cat02"
+	case CAT16:
+		return "cat16"
+	default:
+		return "unknown"
+	}
+}
+
+// matrices returns the transform's XYZ to cone response matrix and its inverse.
+func (m CAT) matrices() (toLMS, fromLMS [9]float64) {
+	switch m {
+	case CATXYZScaling:
+		identity := [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+		return identity, identity
+	case CATVonKries:
+		return vonKriesMatrix, vonKriesMatrixInv
+	case CATSharp:
+		return sharpMatrix, sharpMatrixInv
+	case CAT02:
+		return cat02Matrix, cat02MatrixInv
+	case CAT16:
+		return cam16M, cam16MInv
+	default:
+		return bradfordMatrix, bradfordMatrixInv
+	}
+}
+
+// ChromaticAdaptation configures how colors are adapted from one white point
+// to another: the transform, and how completely the observer adapts.
+type ChromaticAdaptation struct {
+	Method CAT
+
+	// Degree is the degree of adaptation D, from 0 (none) to 1 (complete).
+	// The zero value does not adapt, so NewRGBSpace rejects it for a white
+	// other than D65; use 1 for complete adaptation.
+	// DegreeOfAdaptation estimates it for viewing conditions.
+	Degree float64
+}
+
+// bradfordAdaptation is complete Bradford adaptation, used by the built-in
+// spaces whose white is not D65, such as ProPhotoRGBSpace and the ACES spaces.
+var bradfordAdaptation = ChromaticAdaptation{Method: CATBradford, Degree: 1}
+
+// DegreeOfAdaptation estimates the degree of adaptation D from the luminance
+// of the adapting field in cd/m² and the surround, as in CIECAM02 and CAM16.
+// Adaptation is more complete in brighter conditions.
+func DegreeOfAdaptation(adaptingLuminance float64, surround Surround) float64 {
+	f, _, _ := surround.factors()
+	return clamp01(f * (1 - (1/3.6)*math.Exp((-adaptingLuminance-42)/92)))
+}
+
+// Adapt adapts XYZ values seen under one white point to the corresponding
+// colors under another, so that the from white becomes the to white.
+//
+// Example:
+//   // A color measured under tungsten light, as it would appear in daylight
+//   x, y, z := color.Adapt(0.45, 0.40, 0.15, color.WhiteA, color.WhiteD65, color.CATBradford)
+func Adapt(x, y, z float64, from, to WhitePoint, method CAT) (float64, float64, float64) {
+	return ChromaticAdaptation{Method: method, Degree: 1}.Adapt(x, y, z, from, to)
+}
+
+// Adapt adapts XYZ values seen under one white point to the corresponding
+// colors under another. With a Degree below 1 the from white is only
+// partially moved towards the to white.
+//
+// Example:
+//   // An observer in a dim room, only partly adapted to a D50 print
+//   a := color.ChromaticAdaptation{Method: color.CAT16, Degree: color.DegreeOfAdaptation(20, color.SurroundDim)}
+//   x, y, z := a.Adapt(0.2, 0.3, 0.4, color.WhiteD65, color.WhiteD50)
+func (a ChromaticAdaptation) Adapt(x, y, z float64, from, to WhitePoint) (float64, float64, float64) {
+	return a.adapt(x, y, z, from.xyz(), to.xyz())
+}
+
 // adaptWhitePoint performs chromatic adaptation using the Bradford transform.
 // This adapts XYZ values from one white point to another.
 func adaptWhitePoint(x, y, z float64, sourceWhite, destWhite [3]float64) (float64, float64, float64) {
-	// Convert XYZ to LMS (cone response) using Bradford matrix
-	m := bradfordMatrix
-	lSource := m[0]*x + m[1]*y + m[2]*z
-	mSource := m[3]*x + m[4]*y + m[5]*z
-	sSource := m[6]*x + m[7]*y + m[8]*z
-
-	// Get LMS for source and destination white points
-	lWhiteSrc := m[0]*sourceWhite[0] + m[1]*sourceWhite[1] + m[2]*sourceWhite[2]
-	mWhiteSrc := m[3]*sourceWhite[0] + m[4]*sourceWhite[1] + m[5]*sourceWhite[2]
-	sWhiteSrc := m[6]*sourceWhite[0] + m[7]*sourceWhite[1] + m[8]*sourceWhite[2]
-
-	lWhiteDst := m[0]*destWhite[0] + m[1]*destWhite[1] + m[2]*destWhite[2]
-	mWhiteDst := m[3]*destWhite[0] + m[4]*destWhite[1] + m[5]*destWhite[2]
-	sWhiteDst := m[6]*destWhite[0] + m[7]*destWhite[1] + m[8]*destWhite[2]
-
-	// Scale the LMS values
-	lAdapted := lSource * (lWhiteDst / lWhiteSrc)
-	mAdapted := mSource * (mWhiteDst / mWhiteSrc)
-	sAdapted := sSource * (sWhiteDst / sWhiteSrc)
-
-	// Convert back to XYZ using inverse Bradford matrix
-	mi := bradfordMatrixInv
-	xAdapted := mi[0]*lAdapted + mi[1]*mAdapted + mi[2]*sAdapted
-	yAdapted := mi[3]*lAdapted + mi[4]*mAdapted + mi[5]*sAdapted
-	zAdapted := mi[6]*lAdapted + mi[7]*mAdapted + mi[8]*sAdapted
-
-	return xAdapted, yAdapted, zAdapted
+	return bradfordAdaptation.adapt(x, y, z, sourceWhite, destWhite)
+}
+
+// adapt adapts XYZ values from one white to another.
+func (a ChromaticAdaptation) adapt(x, y, z float64, sourceWhite, destWhite [3]float64) (float64, float64, float64) {
+	return a.scale(x, y, z, sourceWhite, destWhite, false)
+}
+
+// unadapt inverts adapt with the same whites. With complete adaptation this
+// is adapting from destWhite to sourceWhite.
+func (a ChromaticAdaptation) unadapt(x, y, z float64, sourceWhite, destWhite [3]float64) (float64, float64, float64) {
+	return a.scale(x, y, z, sourceWhite, destWhite, true)
+}
+
+// scale converts XYZ to cone responses, scales each by the ratio of the
+// whites (or its inverse), and converts back.
+func (a ChromaticAdaptation) scale(x, y, z float64, sourceWhite, destWhite [3]float64, inverse bool) (float64, float64, float64) {
+	d := math.Min(a.Degree, 1)
+	if sourceWhite == destWhite || !(d > 0) {
+		return x, y, z
+	}
+	toLMS, fromLMS := a.Method.matrices()
+
+	// Convert XYZ and both whites to cone responses
+	lms := mulMatrix3(toLMS, x, y, z)
+	src := mulMatrix3(toLMS, sourceWhite[0], sourceWhite[1], sourceWhite[2])
+	dst := mulMatrix3(toLMS, destWhite[0], destWhite[1], destWhite[2])
+
+	// Scale each cone response by the ratio of the whites, for the degree of adaptation
+	for i := range lms {
+		k := d*dst[i]/src[i] + 1 - d
+		if inverse {
+			lms[i] /= k
+		} else {
+			lms[i] *= k
+		}
+	}
+
+	// Convert back to XYZ
+	xyz := mulMatrix3(fromLMS, lms[0], lms[1], lms[2])
+	return xyz[0], xyz[1], xyz[2]
 }
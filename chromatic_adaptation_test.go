@@ -132,3 +132,136 @@ func BenchmarkAdaptD50ToD65(b *testing.B) {
 		AdaptD50ToD65(x, y, z)
 	}
 }
+
+func TestCATReference(t *testing.T) {
+	// From the colour-science von Kries adaptation with CAT02
+	from := WhitePoint{X: 0.95045593, Y: 1, Z: 1.08905775}
+	to := WhitePoint{X: 0.96429568, Y: 1, Z: 0.82510460}
+	x, y, z := Adapt(0.20654008, 0.12197225, 0.05136952, from, to, CAT02)
+	if !floatNear(x, 0.2163881, 1e-6) || !floatNear(y, 0.1257, 1e-6) || !floatNear(z, 0.0384749, 1e-6) {
+		t.Errorf("CAT02 = %g, %g, %g", x, y, z)
+	}
+}
+
+func TestCATs(t *testing.T) {
+	for _, method := range []CAT{CATBradford, CATXYZScaling, CATVonKries, CATSharp, CAT02, CAT16} {
+		if method.String() == "unknown" {
+			t.Errorf("CAT %d has no name", method)
+		}
+
+		// The source white becomes the destination white
+		x, y, z := Adapt(WhiteD65.X, 1, WhiteD65.Z, WhiteD65, WhiteA, method)
+		if !floatNear(x, WhiteA.X, 1e-6) || !floatNear(y, 1, 1e-6) || !floatNear(z, WhiteA.Z, 1e-6) {
+			t.Errorf("%s: D65 under A = %g, %g, %g", method, x, y, z)
+		}
+
+		// Partial adaptation moves the white only part of the way, and inverts exactly
+		half := ChromaticAdaptation{Method: method, Degree: 0.5}
+		x, _, z = half.Adapt(WhiteD65.X, 1, WhiteD65.Z, WhiteD65, WhiteA)
+		if x <= WhiteD65.X || x >= WhiteA.X || z >= WhiteD65.Z || z <= WhiteA.Z {
+			t.Errorf("%s: half-adapted white = %g, _, %g", method, x, z)
+		}
+		x, y, z = half.adapt(0.2, 0.3, 0.4, whiteD65, whiteD50)
+		x, y, z = half.unadapt(x, y, z, whiteD65, whiteD50)
+		if !floatNear(x, 0.2, 1e-12) || !floatNear(y, 0.3, 1e-12) || !floatNear(z, 0.4, 1e-12) {
+			t.Errorf("%s: partial round trip = %g, %g, %g", method, x, y, z)
+		}
+	}
+}
+
+func TestDegreeOfAdaptation(t *testing.T) {
+	if d := DegreeOfAdaptation(318.31, SurroundAverage); !floatNear(d, 0.9944, 1e-4) {
+		t.Errorf("D at 318 cd/m² = %g", d)
+	}
+	bright, dark := DegreeOfAdaptation(200, SurroundAverage), DegreeOfAdaptation(200, SurroundDark)
+	if dark >= bright {
+		t.Errorf("D in a dark surround = %g, average = %g", dark, bright)
+	}
+	if dim := DegreeOfAdaptation(0, SurroundAverage); dim >= bright {
+		t.Errorf("D at 0 cd/m² = %g", dim)
+	}
+}
+
+func TestRGBSpaceAdaptation(t *testing.T) {
+	red := NewSpaceColor(ProPhotoRGBSpace, []float64{0.8, 0.2, 0.1}, 1)
+	bradford := red.ConvertTo(SRGBLinearSpace).Channels()
+
+	prophoto16, err := NewRGBSpace("prophoto-cat16", proPhotoPrimaries, WhiteD50, TransferGamma(1.8), ChromaticAdaptation{Method: CAT16, Degree: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	red16 := NewSpaceColor(prophoto16, red.Channels(), 1)
+	cat16 := red16.ConvertTo(SRGBLinearSpace).Channels()
+	if floatNear(cat16[0], bradford[0], 1e-6) && floatNear(cat16[2], bradford[2], 1e-6) {
+		t.Errorf("CAT16 = %v, same as Bradford", cat16)
+	}
+
+	// The built-in space is unchanged
+	if got := red.ConvertTo(SRGBLinearSpace).Channels(); got[0] != bradford[0] || got[2] != bradford[2] {
+		t.Errorf("ProPhoto = %v, want %v", got, bradford)
+	}
+
+	// Whites still map to whites, and conversions still round trip
+	white := NewSpaceColor(prophoto16, []float64{1, 1, 1}, 1).ConvertTo(SRGBLinearSpace).Channels()
+	back := NewSpaceColor(SRGBLinearSpace, cat16, 1).ConvertTo(prophoto16).Channels()
+	for i := range white {
+		if !floatNear(white[i], 1, 1e-6) {
+			t.Errorf("ProPhoto white with CAT16 = %v", white)
+			break
+		}
+		if !floatNear(back[i], red.Channels()[i], 1e-6) {
+			t.Errorf("round trip with CAT16 = %v", back)
+			break
+		}
+	}
+}
+
+func TestNoAdaptation(t *testing.T) {
+	// A degree of 0 passes XYZ through unchanged
+	none := ChromaticAdaptation{Method: CATBradford}
+	if x, y, z := none.Adapt(0.2, 0.3, 0.4, WhiteD65, WhiteA); x != 0.2 || y != 0.3 || z != 0.4 {
+		t.Errorf("D = 0: %g, %g, %g", x, y, z)
+	}
+	if x, y, z := none.unadapt(0.2, 0.3, 0.4, whiteD50, whiteD65); x != 0.2 || y != 0.3 || z != 0.4 {
+		t.Errorf("D = 0 inverse: %g, %g, %g", x, y, z)
+	}
+
+	// So a D50 LAB space is relative to D50 XYZ read as D65 XYZ
+	x, y, z := 0.2, 0.3, 0.4
+	got := NewLABSpace(WhiteD50, none).FromXYZ(x, y, z)
+	l, a, b := xyzToLAB(x, y, z, whiteD50)
+	if got[0] != l || got[1] != a || got[2] != b {
+		t.Errorf("unadapted D50 LAB = %v, want %g, %g, %g", got, l, a, b)
+	}
+}
+
+func TestNewLABSpace(t *testing.T) {
+	labD50 := NewLABSpace(WhiteD50, bradfordAdaptation)
+	if labD50.Name() != "lab-d50" {
+		t.Errorf("name = %q", labD50.Name())
+	}
+
+	// CSS Color 4 lab() is D50 with Bradford adaptation
+	red := NewSpaceColor(SRGBSpace, []float64{1, 0, 0}, 1).ConvertTo(labD50).Channels()
+	for i, v := range []float64{54.29, 80.81, 69.89} {
+		if !floatNear(red[i], v, 0.05) {
+			t.Errorf("sRGB red in D50 LAB = %v", red)
+			break
+		}
+	}
+	back := NewSpaceColor(labD50, red, 1).ConvertTo(SRGBSpace).Channels()
+	for i, v := range []float64{1, 0, 0} {
+		if !floatNear(back[i], v, 1e-5) {
+			t.Errorf("round trip = %v", back)
+			break
+		}
+	}
+
+	// Relative to D65 it is the LAB type
+	c := RGB(0.2, 0.4, 0.6)
+	want := ToLAB(c)
+	got := NewLABSpace(WhiteD65, bradfordAdaptation).FromXYZ(ToXYZ(c).X, ToXYZ(c).Y, ToXYZ(c).Z)
+	if !floatNear(got[0], want.L, 1e-9) || !floatNear(got[1], want.A, 1e-9) || !floatNear(got[2], want.B, 1e-9) {
+		t.Errorf("D65 LAB = %v, want %v", got, want)
+	}
+}
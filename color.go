@@ -31,12 +31,12 @@ func NewRGBA(r, g, b, a float64) *RGBA {
 
 // RGBA implements Color.
 func (c *RGBA) RGBA() (r, g, b, a float64) {
-	return c.R, c.G, c.B, c.A
+	return resolveNone(c.R), resolveNone(c.G), resolveNone(c.B), resolveNone(c.A)
 }
 
 // Alpha implements Color.
 func (c *RGBA) Alpha() float64 {
-	return c.A
+	return resolveNone(c.A)
 }
 
 // WithAlpha implements Color.
@@ -49,6 +49,39 @@ func (c *RGBA) String() string {
 	return RGBToHex(c)
 }
 
+// linearColor is implemented by colors that can report linear-light sRGB
+// components without clamping them to [0, 1].
+type linearColor interface {
+	linearRGBA() (r, g, b, a float64)
+}
+
+// LinearRGBA returns the linear-light sRGB components of a color without clamping.
+// Colors outside the sRGB gamut produce components below 0 or above 1, which is
+// what InGamut and MapToGamut rely on to detect them.
+func LinearRGBA(c Color) (r, g, b, a float64) {
+	if lc, ok := c.(linearColor); ok {
+		return lc.linearRGBA()
+	}
+	r, g, b, a = c.RGBA()
+	return inverseGammaCorrection(r), inverseGammaCorrection(g), inverseGammaCorrection(b), a
+}
+
+// UnclampedRGBA returns the gamma-encoded sRGB components of a color without clamping.
+// Unlike Color.RGBA, out-of-gamut colors keep their out-of-range values.
+func UnclampedRGBA(c Color) (r, g, b, a float64) {
+	if rgba, ok := c.(*RGBA); ok {
+		return rgba.RGBA()
+	}
+	r, g, b, a = LinearRGBA(c)
+	return gammaCorrection(r), gammaCorrection(g), gammaCorrection(b), a
+}
+
+// linearRGBA implements linearColor.
+func (c *RGBA) linearRGBA() (r, g, b, a float64) {
+	r, g, b, a = c.RGBA()
+	return inverseGammaCorrection(r), inverseGammaCorrection(g), inverseGammaCorrection(b), a
+}
+
 // clamp01 clamps a value to the range [0, 1].
 func clamp01(v float64) float64 {
 	if v < 0 {
@@ -11,7 +11,7 @@ package color
 func ConvertToRGBSpace(c Color, spaceName string) (SpaceColor, error) {
 	space := getSpaceByName(spaceName)
 	if space == nil {
-		return nil, &ParseError{input: spaceName, reason: "unknown RGB color space"}
+		return nil, newParseError(ErrUnknownColorSpace, spaceName, "unknown RGB color space")
 	}
 
 	// Convert color to XYZ first
@@ -33,7 +33,7 @@ func ConvertToRGBSpace(c Color, spaceName string) (SpaceColor, error) {
 func ConvertFromRGBSpace(r, g, b, a float64, spaceName string) (Color, error) {
 	space := getSpaceByName(spaceName)
 	if space == nil {
-		return nil, &ParseError{input: spaceName, reason: "unknown RGB color space"}
+		return nil, newParseError(ErrUnknownColorSpace, spaceName, "unknown RGB color space")
 	}
 
 	// Create a SpaceColor in the source space
@@ -153,25 +153,25 @@ func TestGamutMappingInternals(t *testing.T) {
 	vivid := NewOKLCH(0.7, 0.35, 150, 1.0)
 
 	// Test mapClip
-	clipped := mapClip(vivid)
+	clipped := mapClip(vivid, SRGBSpace)
 	if !InGamut(clipped) {
 		t.Error("mapClip produced out-of-gamut color")
 	}
 
 	// Test mapPreserveLightness
-	lightness := mapPreserveLightness(vivid)
+	lightness := mapPreserveLightness(vivid, SRGBSpace)
 	if !InGamut(lightness) {
 		t.Error("mapPreserveLightness produced out-of-gamut color")
 	}
 
 	// Test mapPreserveChroma
-	chroma := mapPreserveChroma(vivid)
+	chroma := mapPreserveChroma(vivid, SRGBSpace)
 	if !InGamut(chroma) {
 		t.Error("mapPreserveChroma produced out-of-gamut color")
 	}
 
 	// Test mapProject
-	project := mapProject(vivid)
+	project := mapProject(vivid, SRGBSpace)
 	if !InGamut(project) {
 		t.Error("mapProject produced out-of-gamut color")
 	}
@@ -179,7 +179,7 @@ func TestGamutMappingInternals(t *testing.T) {
 
 // Test Error() method
 func TestParseError(t *testing.T) {
-	err := &ParseError{input: "invalid", reason: "test reason"}
+	err := &ParseError{Input: "invalid", Reason: "test reason"}
 	errStr := err.Error()
 	if errStr == "" {
 		t.Error("ParseError.Error() returned empty string")
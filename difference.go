@@ -2,6 +2,9 @@ package color
 
 import "math"
 
+// DeltaEFunc is a color difference metric such as DeltaE2000 or DeltaEOK.
+type DeltaEFunc func(c1, c2 Color) float64
+
 // DeltaEOK calculates the perceptual difference between two colors using OKLAB space.
 // This is a simpler and faster alternative to DeltaE2000, using Euclidean distance in OKLAB.
 // Returns a value where 0 means identical colors, and larger values mean more different.
@@ -140,3 +143,20 @@ func DeltaE2000(c1, c2 Color) float64 {
 
 	return deltaE
 }
+
+// DeltaEITP calculates the color difference of ITU-R BT.2124 for HDR and
+// wide-gamut video, in ICtCp (PQ) with SDR white at SDRWhiteLuminance.
+// A value of 1 is about one just-noticeable difference.
+func DeltaEITP(c1, c2 Color) float64 {
+	xyz1 := ToXYZ(c1)
+	xyz2 := ToXYZ(c2)
+	itp1 := ICtCpSpace.FromXYZ(xyz1.X, xyz1.Y, xyz1.Z)
+	itp2 := ICtCpSpace.FromXYZ(xyz2.X, xyz2.Y, xyz2.Z)
+
+	// T = 0.5 Ct, P = Cp
+	di := itp1[0] - itp2[0]
+	dt := 0.5 * (itp1[1] - itp2[1])
+	dp := itp1[2] - itp2[2]
+
+	return 720 * math.Sqrt(di*di+dt*dt+dp*dp)
+}
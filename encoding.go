@@ -0,0 +1,365 @@
+package color
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Value holds any Color for use in struct fields, JSON and database columns,
+// keeping the parsed type (and thus the gamut) of the color as is.
+// A nil Color is written as JSON null and SQL NULL, and is what null reads back as.
+//
+// Format sets how the color is written; nil means DefaultFormatOptions.
+// Reading never changes it, so a Value can be given a format before decoding
+// into it. Input always goes through ParseColor, so any CSS color string is accepted.
+//
+// Example:
+//   type Theme struct {
+//       Accent color.Value `json:"accent"`
+//   }
+//   json.Unmarshal([]byte(`{"accent": "oklch(0.7 0.15 240)"}`), &theme)
+//   theme.Accent.Color  // *OKLCH
+//
+//   // Store colors as hex
+//   hex := color.FormatOptions{Hex: true}
+//   v := color.Value{Color: c, Format: &hex}
+type Value struct {
+	Color  Color
+	Format *FormatOptions
+}
+
+// format returns the options v is written with.
+func (v Value) format() FormatOptions {
+	if v.Format == nil {
+		return DefaultFormatOptions
+	}
+	return *v.Format
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Value) MarshalText() ([]byte, error) {
+	if v.Color == nil {
+		return []byte{}, nil
+	}
+	return []byte(Format(v.Color, v.format())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Empty text gives a nil Color.
+func (v *Value) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		v.Color = nil
+		return nil
+	}
+	c, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	v.Color = c
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Value) MarshalJSON() ([]byte, error) {
+	if v.Color == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(Format(v.Color, v.format()))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		v.Color = nil
+		return nil
+	}
+	return unmarshalColorJSON(data, v)
+}
+
+// Scan implements sql.Scanner.
+func (v *Value) Scan(src interface{}) error {
+	if src == nil {
+		v.Color = nil
+		return nil
+	}
+	return scanColor(src, v)
+}
+
+// Value implements driver.Valuer.
+func (v Value) Value() (driver.Value, error) {
+	if v.Color == nil {
+		return nil, nil
+	}
+	return Format(v.Color, v.format()), nil
+}
+
+// marshalColor writes c with DefaultFormatOptions, as CSSString does.
+func marshalColor(c Color) ([]byte, error) {
+	return []byte(CSSString(c)), nil
+}
+
+// marshalColorJSON writes c as a JSON string with DefaultFormatOptions.
+func marshalColorJSON(c Color) ([]byte, error) {
+	return json.Marshal(CSSString(c))
+}
+
+// valueColor writes c for driver.Valuer with DefaultFormatOptions.
+func valueColor(c Color) (driver.Value, error) {
+	return CSSString(c), nil
+}
+
+// unmarshalInto parses text into dst. A color that already has dst's type is
+// kept unchanged, including missing components; any other is converted.
+func unmarshalInto[T any, P interface {
+	*T
+	Color
+}](dst P, text []byte, convert func(Color) P) error {
+	parsed, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	if v, ok := parsed.(P); ok {
+		*dst = *v
+	} else {
+		*dst = *convert(parsed)
+	}
+	return nil
+}
+
+// unmarshalColorJSON reads a JSON string into dst.
+func unmarshalColorJSON(data []byte, dst interface{ UnmarshalText([]byte) error }) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("color: JSON value must be a string: %w", err)
+	}
+	return dst.UnmarshalText([]byte(s))
+}
+
+// scanColor reads a database string or []byte into dst.
+func scanColor(src interface{}, dst interface{ UnmarshalText([]byte) error }) error {
+	switch v := src.(type) {
+	case string:
+		return dst.UnmarshalText([]byte(v))
+	case []byte:
+		return dst.UnmarshalText(v)
+	}
+	return fmt.Errorf("color: cannot scan %T into a color", src)
+}
+
+// toRGBA converts c to sRGB, keeping an *RGBA as is.
+func toRGBA(c Color) *RGBA {
+	r, g, b, a := c.RGBA()
+	return &RGBA{R: r, G: g, B: b, A: a}
+}
+
+// The concrete types below are written with DefaultFormatOptions; use Value
+// for another format. They read any CSS color and convert it to their own
+// model; a color that already has the right type is kept unchanged,
+// including missing components.
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *RGBA) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *RGBA) UnmarshalText(text []byte) error { return unmarshalInto(c, text, toRGBA) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *RGBA) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *RGBA) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *RGBA) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *RGBA) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *HSL) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *HSL) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToHSL) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *HSL) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *HSL) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *HSL) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *HSL) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *HSV) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *HSV) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToHSV) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *HSV) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *HSV) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *HSV) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *HSV) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *HWB) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *HWB) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToHWB) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *HWB) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *HWB) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *HWB) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *HWB) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *LAB) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *LAB) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToLAB) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *LAB) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *LAB) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *LAB) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *LAB) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *LCH) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *LCH) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToLCH) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *LCH) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *LCH) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *LCH) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *LCH) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *OKLAB) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *OKLAB) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToOKLAB) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *OKLAB) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *OKLAB) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *OKLAB) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *OKLAB) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *OKLCH) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *OKLCH) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToOKLCH) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *OKLCH) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *OKLCH) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *OKLCH) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *OKLCH) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *XYZ) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *XYZ) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToXYZ) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *XYZ) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *XYZ) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *XYZ) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *XYZ) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *LUV) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *LUV) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToLUV) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *LUV) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *LUV) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *LUV) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *LUV) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c *LCHuv) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *LCHuv) UnmarshalText(text []byte) error { return unmarshalInto(c, text, ToLCHuv) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *LCHuv) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *LCHuv) UnmarshalJSON(data []byte) error { return unmarshalColorJSON(data, c) }
+
+// Scan implements sql.Scanner.
+func (c *LCHuv) Scan(src interface{}) error { return scanColor(src, c) }
+
+// Value implements driver.Valuer.
+func (c *LCHuv) Value() (driver.Value, error) { return valueColor(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+// SpaceColor is an interface, so reading one back goes through Value.
+func (c *spaceColor) MarshalText() ([]byte, error) { return marshalColor(c) }
+
+// MarshalJSON implements json.Marshaler.
+func (c *spaceColor) MarshalJSON() ([]byte, error) { return marshalColorJSON(c) }
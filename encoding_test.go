@@ -0,0 +1,180 @@
+package color
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSONConcreteTypes(t *testing.T) {
+	type theme struct {
+		Accent *OKLCH `json:"accent"`
+		Text   *RGBA  `json:"text"`
+		Border *HSL   `json:"border,omitempty"`
+	}
+
+	in := theme{
+		Accent: NewOKLCH(0.7, 0.15, 240, 1),
+		Text:   NewRGBA(0, 0, 0, 0.87),
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"accent":"oklch(0.7 0.15 240)","text":"rgb(0 0 0 / 0.87)"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	var out theme
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if *out.Accent != *in.Accent {
+		t.Errorf("Accent = %+v, want %+v", *out.Accent, *in.Accent)
+	}
+	if *out.Text != *in.Text {
+		t.Errorf("Text = %+v, want %+v", *out.Text, *in.Text)
+	}
+	if out.Border != nil {
+		t.Errorf("Border = %v, want nil", out.Border)
+	}
+}
+
+func TestUnmarshalTextConverts(t *testing.T) {
+	var c OKLCH
+	if err := c.UnmarshalText([]byte("#ff0000")); err != nil {
+		t.Fatal(err)
+	}
+	if DeltaEOK(&c, RGB(1, 0, 0)) > 1e-6 {
+		t.Errorf("UnmarshalText(#ff0000) = %+v", c)
+	}
+
+	var hsl HSL
+	if err := hsl.UnmarshalText([]byte("hsl(120 100% 50% / none)")); err != nil {
+		t.Fatal(err)
+	}
+	if !HasMissing(&hsl) {
+		t.Error("UnmarshalText into the parsed type should keep missing components")
+	}
+
+	var lab LAB
+	if err := lab.UnmarshalText([]byte("not a color")); err == nil {
+		t.Error("expected error for invalid color")
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	var c RGBA
+	if err := json.Unmarshal([]byte(`42`), &c); err == nil {
+		t.Error("expected error for non-string JSON")
+	}
+	if err := json.Unmarshal([]byte(`"rgb(1 2)"`), &c); err == nil {
+		t.Error("expected error for invalid color string")
+	}
+}
+
+func TestValueWrapper(t *testing.T) {
+	type config struct {
+		Background Value `json:"background"`
+		Foreground Value `json:"foreground"`
+	}
+
+	var cfg config
+	err := json.Unmarshal([]byte(`{"background":"color(display-p3 0 1 0)","foreground":null}`), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.Background.Color.(SpaceColor); !ok {
+		t.Errorf("Background = %T, want SpaceColor", cfg.Background.Color)
+	}
+	if cfg.Foreground.Color != nil {
+		t.Errorf("Foreground = %v, want nil", cfg.Foreground.Color)
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"background":"color(display-p3 0 1 0)","foreground":null}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestScanAndValue(t *testing.T) {
+	var c OKLAB
+	if err := c.Scan([]byte("oklab(0.5 0.1 -0.1)")); err != nil {
+		t.Fatal(err)
+	}
+	if c != *NewOKLAB(0.5, 0.1, -0.1, 1) {
+		t.Errorf("Scan = %+v", c)
+	}
+
+	v, err := c.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != driver.Value("oklab(0.5 0.1 -0.1)") {
+		t.Errorf("Value() = %v", v)
+	}
+
+	if err := c.Scan(42); err == nil {
+		t.Error("expected error scanning an int")
+	}
+
+	var w Value
+	if err := w.Scan("red"); err != nil || w.Color == nil {
+		t.Fatalf("Scan(red) = %v, %v", w.Color, err)
+	}
+	if err := w.Scan(nil); err != nil || w.Color != nil {
+		t.Errorf("Scan(nil) = %v, %v", w.Color, err)
+	}
+	if v, err := w.Value(); v != nil || err != nil {
+		t.Errorf("Value() of nil color = %v, %v", v, err)
+	}
+}
+
+func TestValueFormat(t *testing.T) {
+	hex := FormatOptions{Hex: true}
+	v := Value{Color: NewRGBA(1, 0, 0, 1), Format: &hex}
+
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "#ff0000" {
+		t.Errorf("MarshalText = %s, want #ff0000", text)
+	}
+	if dv, _ := v.Value(); dv != driver.Value("#ff0000") {
+		t.Errorf("Value() = %v, want #ff0000", dv)
+	}
+
+	// Decoding keeps the format
+	if err := json.Unmarshal([]byte(`"oklch(0.7 0.15 240)"`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Format != &hex {
+		t.Error("UnmarshalJSON should keep Format")
+	}
+
+	// Colors hex cannot hold keep their precision
+	p3 := Value{Color: NewSpaceColor(DisplayP3Space, []float64{0.2, 0.9, 0.3}, 0.5), Format: &hex}
+	data, err := json.Marshal(p3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Value
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if d := DeltaEOK(p3.Color, back.Color); d > 1e-4 || back.Color.Alpha() != 0.5 {
+		t.Errorf("%s read back as %v", data, back.Color)
+	}
+
+	// Concrete types always use DefaultFormatOptions
+	text, _ = NewRGBA(1, 0, 0, 1).MarshalText()
+	if string(text) != "rgb(255 0 0)" {
+		t.Errorf("MarshalText = %s, want rgb(255 0 0)", text)
+	}
+}
@@ -29,7 +29,7 @@ func ExampleCLogSpace() {
 
 	// Output:
 	// C-Log color: 0.45, 0.40, 0.35
-	// sRGB for display: 0.700, 0.593, 0.513
+	// sRGB for display: 0.742, 0.595, 0.476
 }
 
 // ExampleSLog3Space demonstrates working with Sony S-Log3 color space
@@ -55,7 +55,7 @@ func ExampleSLog3Space() {
 
 	// Output:
 	// S-Log3 color: 0.50, 0.42, 0.38
-	// Display P3 for HDR: 1.000, 0.262, 0.214
+	// Display P3 for HDR: 0.805, 0.448, 0.377
 }
 
 // ExampleVLogSpace demonstrates working with Panasonic V-Log
@@ -81,7 +81,7 @@ func ExampleVLogSpace() {
 
 	// Output:
 	// Original V-Log: 0.48, 0.45, 0.40
-	// Display P3: 0.661, 0.528, 0.375
+	// Display P3: 0.636, 0.526, 0.405
 }
 
 // ExampleArriLogCSpace demonstrates working with Arri LogC
@@ -108,7 +108,7 @@ func ExampleArriLogCSpace() {
 
 	// Output:
 	// Arri LogC color: 0.42, 0.38, 0.35
-	// Rec.2020 for HDR: 0.554, 0.484, 0.404
+	// Rec.2020 for HDR: 0.553, 0.484, 0.408
 }
 
 // ExampleRedLog3G10Space demonstrates working with Red Log3G10
@@ -135,7 +135,7 @@ func ExampleRedLog3G10Space() {
 
 	// Output:
 	// Red Log3G10: 0.46, 0.42, 0.38
-	// Display P3: 0.901, 0.686, 0.554
+	// Display P3: 0.903, 0.700, 0.480
 }
 
 // ExampleBMDFilmSpace demonstrates working with Blackmagic Film
@@ -162,7 +162,7 @@ func ExampleBMDFilmSpace() {
 
 	// Output:
 	// BMD Film: 0.44, 0.40, 0.36
-	// sRGB for web: 0.577, 0.451, 0.368
+	// sRGB for web: 0.592, 0.461, 0.336
 }
 
 // Example_logColorGrading demonstrates a typical LOG color grading workflow
@@ -195,8 +195,8 @@ func Example_logColorGrading() {
 
 	// Output:
 	// Original S-Log3: 0.41, 0.39, 0.35
-	// HDR (Rec.2020): 0.489, 0.405, 0.254
-	// SDR (sRGB): 0.515, 0.360, 0.179
+	// HDR (Rec.2020): 0.496, 0.459, 0.380
+	// SDR (sRGB): 0.496, 0.424, 0.334
 }
 
 // Example_logHDRWorkflow demonstrates HDR mastering with LOG footage
@@ -225,7 +225,7 @@ func Example_logHDRWorkflow() {
 
 	// Output:
 	// Arri LogC: 0.65, 0.60, 0.55
-	// Linear light: 1.00, 1.00, 0.84 (HDR > 1.0)
+	// Linear light: 1.00, 1.00, 0.85 (HDR > 1.0)
 	// Contains HDR values: false
 }
 
@@ -165,12 +165,12 @@ func ExampleGradientMultiStop() {
 func ExampleSaturate() {
 	dullColor := color.RGB(0.6, 0.5, 0.55) // Grayish pink
 
-	// Make it more vivid
-	vivid := color.Saturate(dullColor, 0.3) // 30% more saturated
+	// Make it more vivid, staying within the sRGB gamut
+	vivid := color.Saturate(dullColor, 0.3) // 30% of the way to the most vivid sRGB color
 
 	oklch := color.ToOKLCH(vivid)
 	fmt.Printf("Increased chroma: %.2f\n", oklch.C)
-	// Output: Increased chroma: 0.29
+	// Output: Increased chroma: 0.10
 }
 
 // Example demonstrating perceptually uniform operations
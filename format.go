@@ -0,0 +1,248 @@
+package color
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// AlphaFormat controls when Format writes the alpha component.
+type AlphaFormat int
+
+const (
+	// AlphaAuto writes alpha only when it is below 1 (or missing)
+	AlphaAuto AlphaFormat = iota
+	// AlphaAlways writes alpha even for opaque colors
+	AlphaAlways
+	// AlphaNever drops alpha
+	AlphaNever
+)
+
+// FormatOptions controls how Format serializes colors.
+type FormatOptions struct {
+	// Precision is the maximum number of digits after the decimal point.
+	// Trailing zeros are trimmed. 0 means the default of 4, so a
+	// FormatOptions literal never rounds components away. A negative
+	// precision writes the shortest representation that parses back to
+	// exactly the same float64.
+	Precision int
+
+	// Legacy uses the comma-separated syntax for rgb() and hsl(),
+	// e.g. "rgba(255, 0, 0, 0.5)" instead of "rgb(255 0 0 / 0.5)".
+	// Functions without a legacy form ignore it. Missing components
+	// are written as 0, since the legacy syntax has no none keyword.
+	Legacy bool
+
+	// Alpha controls when the alpha component is written.
+	Alpha AlphaFormat
+
+	// Hex writes colors inside the sRGB gamut as #rrggbb, or #rrggbbaa when
+	// alpha is written. Colors outside the gamut, and colors with missing
+	// components, still use a CSS function so nothing is clipped.
+	Hex bool
+}
+
+// DefaultFormatOptions are the options used by CSSString: modern syntax,
+// up to 4 decimal places and alpha only when the color is translucent.
+var DefaultFormatOptions = FormatOptions{Precision: defaultPrecision}
+
+// defaultPrecision is the precision used when FormatOptions.Precision is 0.
+const defaultPrecision = 4
+
+// cssColorSpaces maps Space names to their predefined CSS color() identifiers.
+var cssColorSpaces = map[string]string{
+	"srgb":         "srgb",
+	"srgb-linear":  "srgb-linear",
+	"display-p3":   "display-p3",
+	"a98-rgb":      "a98-rgb",
+	"prophoto-rgb": "prophoto-rgb",
+	"rec2020":      "rec2020",
+}
+
+// CSSString serializes a color to CSS using DefaultFormatOptions.
+//
+// Example:
+//   color.CSSString(color.NewOKLCH(0.7, 0.15, 240, 1))  // "oklch(0.7 0.15 240)"
+//   color.CSSString(color.NewRGBA(1, 0, 0, 0.5))        // "rgb(255 0 0 / 0.5)"
+func CSSString(c Color) string {
+	return Format(c, DefaultFormatOptions)
+}
+
+// Format serializes a color to a CSS Color 4 string that ParseColor reads back.
+//
+// Each type is written in its own CSS function, so no gamut information is lost:
+//   - *RGBA: rgb(), with channels in 0-255, or color(srgb ...) when outside [0, 1]
+//   - *HSL: hsl(); *HWB: hwb()
+//   - *LAB: lab(); *LCH: lch() (lightness as a percentage)
+//   - *OKLAB: oklab(); *OKLCH: oklch()
+//   - *XYZ: color(xyz-d65 ...)
+//   - SpaceColor: color() for the CSS predefined RGB spaces, oklch() for OKLCHSpace,
+//     and color(xyz-d65 ...) for spaces CSS does not know (e.g. LOG spaces)
+//   - Other colors (HSV, LUV, custom types): rgb() when inside the sRGB gamut,
+//     otherwise color(xyz-d65 ...)
+//
+// Missing components (see HasMissing) are written as none.
+func Format(c Color, opts FormatOptions) string {
+	f := formatter{opts: opts}
+
+	if opts.Hex && !HasMissing(c) && InGamut(c) {
+		return f.hex(c)
+	}
+
+	switch v := c.(type) {
+	case *RGBA:
+		if !rgbRange(v.R) || !rgbRange(v.G) || !rgbRange(v.B) {
+			// rgb() clamps its channels, color(srgb) does not
+			return f.modernFunc("color", v.A, "srgb", f.num(v.R), f.num(v.G), f.num(v.B))
+		}
+		return f.rgb(v.R, v.G, v.B, v.A)
+	case *HSL:
+		return f.legacyFunc("hsl", v.A, f.num(v.H), f.pct(v.S*100), f.pct(v.L*100))
+	case *HWB:
+		return f.modernFunc("hwb", v.A, f.num(v.H), f.pct(v.W*100), f.pct(v.B*100))
+	case *LAB:
+		return f.modernFunc("lab", v.A_, f.pct(v.L), f.num(v.A), f.num(v.B))
+	case *LCH:
+		return f.modernFunc("lch", v.A_, f.pct(v.L), f.num(v.C), f.num(v.H))
+	case *OKLAB:
+		return f.modernFunc("oklab", v.A_, f.num(v.L), f.num(v.A), f.num(v.B))
+	case *OKLCH:
+		return f.modernFunc("oklch", v.A_, f.num(v.L), f.num(v.C), f.num(v.H))
+	case *XYZ:
+		return f.xyz(v.X, v.Y, v.Z, v.A)
+	case SpaceColor:
+		return f.spaceColor(v)
+	}
+
+	if InGamut(c) {
+		r, g, b, a := c.RGBA()
+		return f.rgb(r, g, b, a)
+	}
+	xyz := ToXYZ(c)
+	return f.xyz(xyz.X, xyz.Y, xyz.Z, xyz.A)
+}
+
+// rgbRange reports whether rgb() can hold an sRGB channel without clamping.
+// A missing (none) channel is written as none in either form.
+func rgbRange(v float64) bool {
+	return math.IsNaN(v) || inUnitRange(v)
+}
+
+// formatter writes CSS color functions with a set of FormatOptions.
+type formatter struct {
+	opts FormatOptions
+}
+
+// num formats a number, or none for a missing component.
+func (f formatter) num(v float64) string {
+	if math.IsNaN(v) {
+		if f.opts.Legacy {
+			return "0"
+		}
+		return "none"
+	}
+	return formatNumber(v, f.precision())
+}
+
+// pct formats a percentage, or none for a missing component.
+func (f formatter) pct(v float64) string {
+	if math.IsNaN(v) {
+		return f.num(v)
+	}
+	return formatNumber(v, f.precision()) + "%"
+}
+
+// precision returns the number of decimal places to write.
+func (f formatter) precision() int {
+	if f.opts.Precision == 0 {
+		return defaultPrecision
+	}
+	return f.opts.Precision
+}
+
+// includeAlpha reports whether alpha should be written.
+func (f formatter) includeAlpha(alpha float64) bool {
+	switch f.opts.Alpha {
+	case AlphaAlways:
+		return true
+	case AlphaNever:
+		return false
+	}
+	return math.IsNaN(alpha) || alpha < 1
+}
+
+// modernFunc writes a space-separated function: "name(a b c / alpha)".
+func (f formatter) modernFunc(name string, alpha float64, channels ...string) string {
+	s := name + "(" + strings.Join(channels, " ")
+	if f.includeAlpha(alpha) {
+		s += " / " + f.num(alpha)
+	}
+	return s + ")"
+}
+
+// legacyFunc writes a function that also has a legacy form:
+// "name(a, b, c)" or "namea(a, b, c, alpha)" with Legacy set, modernFunc otherwise.
+func (f formatter) legacyFunc(name string, alpha float64, channels ...string) string {
+	if !f.opts.Legacy {
+		return f.modernFunc(name, alpha, channels...)
+	}
+	if f.includeAlpha(alpha) {
+		return name + "a(" + strings.Join(channels, ", ") + ", " + f.num(alpha) + ")"
+	}
+	return name + "(" + strings.Join(channels, ", ") + ")"
+}
+
+// rgb writes sRGB components in [0, 1] as rgb() with channels in 0-255.
+func (f formatter) rgb(r, g, b, alpha float64) string {
+	return f.legacyFunc("rgb", alpha, f.num(r*255), f.num(g*255), f.num(b*255))
+}
+
+// hex writes an sRGB color as #rrggbb or #rrggbbaa, rounding each channel.
+func (f formatter) hex(c Color) string {
+	r, g, b, a := c.RGBA()
+	r8, g8, b8 := roundByte(r), roundByte(g), roundByte(b)
+	if f.includeAlpha(a) {
+		return formatHexWithAlpha(r8, g8, b8, roundByte(a))
+	}
+	return formatHex(r8, g8, b8)
+}
+
+// roundByte converts v in [0, 1] to the nearest byte.
+func roundByte(v float64) uint8 {
+	return uint8(math.Round(clamp01(v) * 255))
+}
+
+// xyz writes CIE XYZ (D65) as color(xyz-d65 ...).
+func (f formatter) xyz(x, y, z, alpha float64) string {
+	return f.modernFunc("color", alpha, "xyz-d65", f.num(x), f.num(y), f.num(z))
+}
+
+// spaceColor writes a SpaceColor in the closest CSS form.
+func (f formatter) spaceColor(c SpaceColor) string {
+	space := c.Space()
+	ch := c.Channels()
+
+	if space == OKLCHSpace && len(ch) == 3 {
+		return f.modernFunc("oklch", c.Alpha(), f.num(ch[0]), f.num(ch[1]), f.num(ch[2]))
+	}
+	if name, ok := cssColorSpaces[strings.ToLower(space.Name())]; ok && len(ch) == 3 {
+		return f.modernFunc("color", c.Alpha(), name, f.num(ch[0]), f.num(ch[1]), f.num(ch[2]))
+	}
+
+	x, y, z := space.ToXYZ(ch)
+	return f.xyz(x, y, z, c.Alpha())
+}
+
+// formatNumber formats v with at most precision decimal places, trimming
+// trailing zeros. A negative precision gives the shortest exact representation.
+func formatNumber(v float64, precision int) string {
+	s := strconv.FormatFloat(v, 'f', precision, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
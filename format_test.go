@@ -0,0 +1,185 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Color
+		opts FormatOptions
+		want string
+	}{
+		{"rgb", NewRGBA(1, 0, 0, 1), DefaultFormatOptions, "rgb(255 0 0)"},
+		{"rgb alpha", NewRGBA(1, 0, 0, 0.5), DefaultFormatOptions, "rgb(255 0 0 / 0.5)"},
+		{"rgb legacy", NewRGBA(1, 0, 0, 1), FormatOptions{Precision: 4, Legacy: true}, "rgb(255, 0, 0)"},
+		{"rgba legacy", NewRGBA(1, 0, 0, 0.5), FormatOptions{Precision: 4, Legacy: true}, "rgba(255, 0, 0, 0.5)"},
+		{"rgb out of gamut", &RGBA{R: 1.2, G: -0.1, B: 0, A: 1}, DefaultFormatOptions, "color(srgb 1.2 -0.1 0)"},
+		{"hsl", NewHSL(120, 1, 0.5, 1), DefaultFormatOptions, "hsl(120 100% 50%)"},
+		{"hsla legacy", NewHSL(120, 1, 0.5, 0.25), FormatOptions{Precision: 4, Legacy: true}, "hsla(120, 100%, 50%, 0.25)"},
+		{"hwb", NewHWB(200, 0.1, 0.2, 1), DefaultFormatOptions, "hwb(200 10% 20%)"},
+		{"hwb ignores legacy", NewHWB(200, 0.1, 0.2, 1), FormatOptions{Precision: 4, Legacy: true}, "hwb(200 10% 20%)"},
+		{"lab", NewLAB(50, 20, -30, 1), DefaultFormatOptions, "lab(50% 20 -30)"},
+		{"lch", NewLCH(70, 45, 180, 0.8), DefaultFormatOptions, "lch(70% 45 180 / 0.8)"},
+		{"oklab", NewOKLAB(0.6, 0.1, -0.1, 1), DefaultFormatOptions, "oklab(0.6 0.1 -0.1)"},
+		{"oklch", NewOKLCH(0.7, 0.15, 240, 1), DefaultFormatOptions, "oklch(0.7 0.15 240)"},
+		{"xyz", NewXYZ(0.5, 0.25, 0.125, 1), DefaultFormatOptions, "color(xyz-d65 0.5 0.25 0.125)"},
+		{"precision", NewOKLCH(0.123456, 0.1, 1.0/3, 1), FormatOptions{Precision: 2}, "oklch(0.12 0.1 0.33)"},
+		{"precision zero is the default", NewRGBA(0.5, 0, 0, 1), FormatOptions{}, "rgb(127.5 0 0)"},
+		{"zero options", NewSpaceColor(DisplayP3Space, []float64{0.2, 0.9, 0.3}, 1), FormatOptions{}, "color(display-p3 0.2 0.9 0.3)"},
+		{"legacy keeps alpha", NewOKLCH(0.7, 0.1, 30, 0.5), FormatOptions{Legacy: true}, "oklch(0.7 0.1 30 / 0.5)"},
+		{"no negative zero", NewOKLAB(0.5, -0.00001, 0, 1), DefaultFormatOptions, "oklab(0.5 0 0)"},
+		{"alpha always", NewRGBA(0, 0, 1, 1), FormatOptions{Precision: 4, Alpha: AlphaAlways}, "rgb(0 0 255 / 1)"},
+		{"alpha never", NewRGBA(0, 0, 1, 0.5), FormatOptions{Precision: 4, Alpha: AlphaNever}, "rgb(0 0 255)"},
+		{"none", &OKLCH{L: 0.7, C: 0.1, H: math.NaN(), A_: 1}, DefaultFormatOptions, "oklch(0.7 0.1 none)"},
+		{"none legacy", &HSL{H: math.NaN(), S: 0, L: 0.5, A: 1}, FormatOptions{Precision: 4, Legacy: true}, "hsl(0, 0%, 50%)"},
+		{"none alpha", &RGBA{R: 1, G: 0, B: 0, A: math.NaN()}, DefaultFormatOptions, "rgb(255 0 0 / none)"},
+		{"none rgb", &RGBA{R: math.NaN(), G: math.NaN(), B: math.NaN(), A: 1}, DefaultFormatOptions, "rgb(none none none)"},
+		{"none rgb out of gamut", &RGBA{R: math.NaN(), G: 1.2, B: 0, A: 1}, DefaultFormatOptions, "color(srgb none 1.2 0)"},
+		{"space display-p3", NewSpaceColor(DisplayP3Space, []float64{1, 0.5, 0}, 1), DefaultFormatOptions, "color(display-p3 1 0.5 0)"},
+		{"space srgb-linear", NewSpaceColor(SRGBLinearSpace, []float64{0.2, 0.4, 0.6}, 0.5), DefaultFormatOptions, "color(srgb-linear 0.2 0.4 0.6 / 0.5)"},
+		{"space oklch", NewSpaceColor(OKLCHSpace, []float64{0.7, 0.15, 240}, 1), DefaultFormatOptions, "oklch(0.7 0.15 240)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.c, tt.opts); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSSStringRoundTrip(t *testing.T) {
+	colors := []Color{
+		NewRGBA(0.2, 0.4, 0.6, 1),
+		NewRGBA(0.2, 0.4, 0.6, 0.3),
+		&RGBA{R: 1.2, G: -0.1, B: 0.5, A: 1},
+		NewHSL(210, 0.6, 0.4, 1),
+		NewHWB(30, 0.2, 0.1, 0.5),
+		NewLAB(53.2, 80.1, 67.2, 1),
+		NewLAB(0.5, 10, 10, 1),
+		NewLCH(29.6, 131.2, 301.4, 1),
+		NewOKLAB(0.45, 0.2, -0.3, 1),
+		NewOKLCH(0.9, 0.37, 145, 0.7),
+		NewXYZ(0.3, 0.6, 0.1, 1),
+		NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1),
+		NewSpaceColor(Rec2020Space, []float64{0, 1, 0}, 1),
+	}
+
+	exact := FormatOptions{Precision: -1}
+	for _, c := range colors {
+		s := Format(c, exact)
+		parsed, err := ParseColor(s)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", s, err)
+			continue
+		}
+		if d := DeltaEOK(c, parsed); d > 1e-6 {
+			t.Errorf("round trip of %q differs by %g", s, d)
+		}
+		if math.Abs(c.Alpha()-parsed.Alpha()) > 1e-12 {
+			t.Errorf("round trip of %q: alpha %g, want %g", s, parsed.Alpha(), c.Alpha())
+		}
+	}
+}
+
+func TestFormatOptionsRoundTrip(t *testing.T) {
+	colors := []Color{
+		NewRGBA(0.2, 0.4, 0.6, 0.3),
+		NewOKLCH(0.7, 0.1, 30, 0.5),
+		NewHSL(210, 0.6, 0.4, 0.25),
+		NewSpaceColor(DisplayP3Space, []float64{0.2, 0.9, 0.3}, 1),
+		NewLAB(53.2, 80.1, 67.2, 0.75),
+	}
+	for _, opts := range []FormatOptions{{}, {Legacy: true}, {Alpha: AlphaAlways}, {Hex: true}} {
+		for _, c := range colors {
+			// Hex is 8 bits per channel, the functions 4 decimals
+			tol := 1e-4
+			if opts.Hex && InGamut(c) {
+				tol = 4e-3
+			}
+			s := Format(c, opts)
+			parsed, err := ParseColor(s)
+			if err != nil {
+				t.Errorf("%+v: ParseColor(%q) error: %v", opts, s, err)
+				continue
+			}
+			if d := DeltaEOK(c, parsed); d > tol {
+				t.Errorf("%+v: round trip of %q differs by %g", opts, s, d)
+			}
+			if math.Abs(c.Alpha()-parsed.Alpha()) > tol {
+				t.Errorf("%+v: round trip of %q: alpha %g, want %g", opts, s, parsed.Alpha(), c.Alpha())
+			}
+		}
+	}
+}
+
+func TestFormatKeepsOutOfGamut(t *testing.T) {
+	// A vivid P3 green is outside sRGB; hex would clip it, Format must not.
+	p3 := NewSpaceColor(DisplayP3Space, []float64{0, 1, 0}, 1)
+	oklch := ToOKLCH(p3)
+	if InGamut(oklch) {
+		t.Fatal("test color should be outside sRGB")
+	}
+
+	parsed, err := ParseColor(CSSString(oklch))
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if InGamut(parsed) {
+		t.Errorf("%s was mapped into sRGB", CSSString(oklch))
+	}
+}
+
+func TestFormatMissingRoundTrip(t *testing.T) {
+	c, err := ParseColor("oklch(0.7 none 120 / none)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := CSSString(c)
+	if s != "oklch(0.7 none 120 / none)" {
+		t.Errorf("CSSString() = %q", s)
+	}
+}
+
+func TestFormatOtherTypes(t *testing.T) {
+	// HSV has no CSS function; inside sRGB it is written as rgb()
+	if got := CSSString(NewHSV(0, 1, 1, 1)); got != "rgb(255 0 0)" {
+		t.Errorf("CSSString(HSV) = %q", got)
+	}
+
+	// Spaces unknown to CSS fall back to color(xyz-d65)
+	logColor := NewSpaceColor(SLog3Space, []float64{0.5, 0.5, 0.5}, 1)
+	s := CSSString(logColor)
+	parsed, err := ParseColor(s)
+	if err != nil {
+		t.Fatalf("ParseColor(%q) error: %v", s, err)
+	}
+	if d := DeltaEOK(logColor, parsed); d > 1e-3 {
+		t.Errorf("%q differs from the original by %g", s, d)
+	}
+}
+
+func TestFormatHex(t *testing.T) {
+	opts := FormatOptions{Precision: 4, Hex: true}
+	tests := []struct {
+		c    Color
+		want string
+	}{
+		{NewRGBA(1, 0.5, 0, 1), "#ff8000"},
+		{NewRGBA(1, 0.5, 0, 0.5), "#ff800080"},
+		{NewHSL(0, 1, 0.5, 1), "#ff0000"},
+		// Outside sRGB: hex would clip, so a CSS function is used
+		{NewOKLCH(0.9, 0.37, 145, 1), "oklch(0.9 0.37 145)"},
+		// Missing components have no hex form
+		{&OKLCH{L: 0.7, C: 0, H: math.NaN(), A_: 1}, "oklch(0.7 0 none)"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.c, opts); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+}
@@ -9,7 +9,7 @@ const (
 	// GamutClip simply clips RGB values to [0, 1]. This is fast but may shift hue.
 	GamutClip GamutMapping = iota
 
-	// GamutPreserveChroma reduces lightness while preserving chroma until the color is in-gamut.
+	// GamutPreserveChroma adjusts lightness while preserving chroma until the color is in-gamut.
 	// This maintains saturation but may significantly alter brightness.
 	GamutPreserveChroma
 
@@ -20,72 +20,211 @@ const (
 	// GamutProject projects the color onto the gamut boundary along the shortest perceptual path.
 	// This balances lightness and chroma adjustments for the most perceptually accurate result.
 	GamutProject
+
+	// GamutCSS implements the CSS Color Module Level 4 gamut mapping algorithm.
+	// It reduces OKLCH chroma by binary search, accepting the clipped color as soon as it is
+	// within a just-noticeable difference (DeltaEOK < 0.02) of the chroma-reduced color.
+	// This matches what browsers render for out-of-gamut CSS colors.
+	GamutCSS
+
+	// GamutRayTrace casts a ray in linear RGB from the achromatic anchor at the color's
+	// OKLCH lightness toward the color, and takes the point where it leaves the gamut.
+	// A few correction passes restore the original lightness and hue. This is the
+	// "raytrace" method of Color.js: close to GamutCSS in quality, with no search loop.
+	GamutRayTrace
+
+	// GamutEdgeSeeker reduces OKLCH chroma to the value stored in a precomputed gamut
+	// boundary table (see GamutBoundaryOf). Lookups are constant time, which makes it
+	// the fastest chroma-reducing mode for palettes and images.
+	GamutEdgeSeeker
 )
 
+// CSS Color 4 gamut mapping constants.
+const (
+	// cssGamutJND is the just-noticeable difference in DeltaEOK used by GamutCSS.
+	cssGamutJND = 0.02
+
+	// cssGamutMinDE is the binary search precision (MINDE) used by GamutCSS.
+	cssGamutMinDE = 0.0001
+)
+
+// gamutEpsilon is the tolerance used when checking RGB components against [0, 1].
+// It absorbs floating-point error from round trips through XYZ and OKLAB.
+const gamutEpsilon = 0.000075
+
 // InGamut checks if a color is within the sRGB gamut.
 // A color is in-gamut if all RGB components are in [0, 1] when converted to sRGB.
+// The check uses unclamped values (see LinearRGBA), so colors such as
+// oklch(0.7 0.4 30) are correctly reported as out of gamut.
 func InGamut(c Color) bool {
-	r, g, b, _ := c.RGBA()
-	return r >= 0 && r <= 1 && g >= 0 && g <= 1 && b >= 0 && b <= 1
+	r, g, b, _ := LinearRGBA(c)
+	return inUnitRange(r) && inUnitRange(g) && inUnitRange(b)
+}
+
+// inUnitRange reports whether v lies in [0, 1] within gamutEpsilon.
+func inUnitRange(v float64) bool {
+	return v >= -gamutEpsilon && v <= 1+gamutEpsilon
+}
+
+// gamutBounded is implemented by color spaces with a finite gamut, such as RGB spaces.
+// Spaces that do not implement it (OKLCH, XYZ, ...) are treated as unbounded.
+type gamutBounded interface {
+	// inGamutXYZ reports whether an XYZ (D65) color lies inside the space's gamut.
+	inGamutXYZ(x, y, z float64) bool
+
+	// clipXYZ converts an XYZ (D65) color to the space, clipping it to the gamut.
+	clipXYZ(x, y, z float64) []float64
+}
+
+// InGamutOf checks if a color is within the gamut of the given color space.
+// Any RGB space (sRGB, Display P3, Rec.2020, A98, ProPhoto, DCI-P3, the LOG spaces,
+// or a custom registered RGB space) has a bounded gamut. Spaces without gamut
+// limits, such as OKLCH, always report true.
+//
+// Example:
+//   p3Red := color.NewSpaceColor(color.DisplayP3Space, []float64{1, 0, 0}, 1.0)
+//   color.InGamutOf(p3Red, color.DisplayP3Space) // true
+//   color.InGamutOf(p3Red, color.SRGBSpace)      // false
+func InGamutOf(c Color, space Space) bool {
+	if space == SRGBSpace {
+		return InGamut(c)
+	}
+	bounded, ok := space.(gamutBounded)
+	if !ok {
+		return true
+	}
+	xyz := ToXYZ(c)
+	return bounded.inGamutXYZ(xyz.X, xyz.Y, xyz.Z)
 }
 
 // MapToGamut maps a color into the sRGB gamut using the specified mapping algorithm.
 // If the color is already in-gamut, it is returned unchanged.
 func MapToGamut(c Color, mapping GamutMapping) Color {
-	if InGamut(c) {
+	return mapToGamut(c, SRGBSpace, mapping)
+}
+
+// MapToGamutOf maps a color into the gamut of the given color space using the specified
+// mapping algorithm, and returns it as a color in that space.
+// For spaces without gamut limits the color is converted without mapping.
+//
+// Example:
+//   vivid := color.NewOKLCH(0.7, 0.35, 150, 1.0)
+//   p3 := color.MapToGamutOf(vivid, color.DisplayP3Space, color.GamutCSS)
+//   // p3.Channels() are Display P3 values in [0, 1]
+func MapToGamutOf(c Color, space Space, mapping GamutMapping) SpaceColor {
+	if InGamutOf(c, space) {
+		return toSpaceColor(c, space)
+	}
+	mapped := mapToGamut(c, space, mapping)
+
+	// The mapped color is in gamut within gamutEpsilon; clip away the
+	// remaining floating-point error so the channels are strictly in range
+	bounded, ok := space.(gamutBounded)
+	if !ok {
+		return toSpaceColor(mapped, space)
+	}
+	xyz := ToXYZ(mapped)
+	return NewSpaceColor(space, bounded.clipXYZ(xyz.X, xyz.Y, xyz.Z), mapped.Alpha())
+}
+
+// mapToGamut maps a color into the gamut of space, returning it unchanged if already in-gamut.
+func mapToGamut(c Color, space Space, mapping GamutMapping) Color {
+	if InGamutOf(c, space) {
 		return c
 	}
 
 	switch mapping {
 	case GamutClip:
-		return mapClip(c)
+		return mapClip(c, space)
 	case GamutPreserveChroma:
-		return mapPreserveChroma(c)
+		return mapPreserveChroma(c, space)
 	case GamutPreserveLightness:
-		return mapPreserveLightness(c)
+		return mapPreserveLightness(c, space)
 	case GamutProject:
-		return mapProject(c)
+		return mapProject(c, space)
+	case GamutCSS:
+		return mapCSS(c, space)
+	case GamutRayTrace:
+		return mapRayTrace(c, space)
+	case GamutEdgeSeeker:
+		return mapEdgeSeeker(c, space)
 	default:
-		return mapClip(c)
+		return mapClip(c, space)
 	}
 }
 
-// mapClip clips RGB values to [0, 1]. Fast but may shift hue.
-func mapClip(c Color) Color {
-	r, g, b, a := c.RGBA()
-	return NewRGBA(clamp01(r), clamp01(g), clamp01(b), a)
+// toSpaceColor converts a color to a SpaceColor in the given space.
+func toSpaceColor(c Color, space Space) SpaceColor {
+	if sc, ok := c.(SpaceColor); ok && sc.Space() == space {
+		return sc
+	}
+	xyz := ToXYZ(c)
+	return NewSpaceColor(space, space.FromXYZ(xyz.X, xyz.Y, xyz.Z), c.Alpha())
 }
 
-// mapPreserveChroma reduces lightness while keeping chroma constant.
-func mapPreserveChroma(c Color) Color {
+// mapClip clips channel values to the gamut of space. Fast but may shift hue.
+// Clipping to sRGB returns an *RGBA; other spaces return a SpaceColor.
+func mapClip(c Color, space Space) Color {
+	if space == SRGBSpace {
+		r, g, b, a := UnclampedRGBA(c)
+		return NewRGBA(clamp01(r), clamp01(g), clamp01(b), a)
+	}
+	bounded, ok := space.(gamutBounded)
+	if !ok {
+		return c
+	}
+	xyz := ToXYZ(c)
+	return NewSpaceColor(space, bounded.clipXYZ(xyz.X, xyz.Y, xyz.Z), c.Alpha())
+}
+
+// mapPreserveChroma adjusts lightness while keeping chroma constant.
+// Lightness is moved toward the cusp (the lightness with the most available
+// chroma for this hue) until the color enters the gamut. If the chroma cannot
+// be reached at any lightness, the cusp itself is returned.
+func mapPreserveChroma(c Color, space Space) Color {
 	oklch := ToOKLCH(c)
 
-	// Binary search for the maximum lightness that keeps the color in-gamut
-	lMin := 0.0
-	lMax := oklch.L
-
-	// If current color is darker, search upward
-	testColor := NewOKLCH(lMax, oklch.C, oklch.H, oklch.Alpha())
-	if !InGamut(testColor) {
-		// Need to darken
-		for i := 0; i < 20; i++ { // 20 iterations gives ~0.0001% precision
-			lMid := (lMin + lMax) / 2
-			testColor = NewOKLCH(lMid, oklch.C, oklch.H, oklch.Alpha())
-			if InGamut(testColor) {
-				lMin = lMid
-			} else {
-				lMax = lMid
-			}
+	cuspL, cuspC := findCusp(oklch.H, space)
+	if oklch.C >= cuspC {
+		return NewOKLCH(cuspL, cuspC, oklch.H, oklch.Alpha())
+	}
+
+	// Binary search between the original lightness (out of gamut) and the
+	// cusp lightness (in gamut) for the boundary
+	lOut := oklch.L
+	lIn := cuspL
+	for i := 0; i < 20; i++ { // 20 iterations gives ~0.0001% precision
+		lMid := (lOut + lIn) / 2
+		testColor := NewOKLCH(lMid, oklch.C, oklch.H, oklch.Alpha())
+		if InGamutOf(testColor, space) {
+			lIn = lMid
+		} else {
+			lOut = lMid
 		}
-		return NewOKLCH(lMin, oklch.C, oklch.H, oklch.Alpha())
 	}
 
-	// If we can't keep the chroma, reduce lightness toward 0
-	return NewOKLCH(0, oklch.C, oklch.H, oklch.Alpha())
+	return NewOKLCH(lIn, oklch.C, oklch.H, oklch.Alpha())
+}
+
+// findCusp finds the lightness and chroma of the gamut cusp of space for a hue in OKLCH.
+// The maximum chroma is unimodal in lightness, so a ternary search converges on it.
+func findCusp(h float64, space Space) (l, c float64) {
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 40; i++ {
+		m1 := lo + (hi-lo)/3
+		m2 := hi - (hi-lo)/3
+		if maxChromaOf(m1, h, space) < maxChromaOf(m2, h, space) {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+	l = (lo + hi) / 2
+	return l, maxChromaOf(l, h, space)
 }
 
 // mapPreserveLightness reduces chroma while keeping lightness constant.
-func mapPreserveLightness(c Color) Color {
+func mapPreserveLightness(c Color, space Space) Color {
 	oklch := ToOKLCH(c)
 
 	// Binary search for the maximum chroma that keeps the color in-gamut
@@ -95,7 +234,7 @@ func mapPreserveLightness(c Color) Color {
 	for i := 0; i < 20; i++ { // 20 iterations gives ~0.0001% precision
 		cMid := (cMin + cMax) / 2
 		testColor := NewOKLCH(oklch.L, cMid, oklch.H, oklch.Alpha())
-		if InGamut(testColor) {
+		if InGamutOf(testColor, space) {
 			cMin = cMid
 		} else {
 			cMax = cMid
@@ -107,7 +246,7 @@ func mapPreserveLightness(c Color) Color {
 
 // mapProject projects the color onto the gamut boundary.
 // This uses a perceptual projection that balances lightness and chroma.
-func mapProject(c Color) Color {
+func mapProject(c Color, space Space) Color {
 	oklch := ToOKLCH(c)
 
 	// Start with the current color
@@ -124,7 +263,7 @@ func mapProject(c Color) Color {
 		testL := oklch.L
 		testColor := NewOKLCH(testL, testC, oklch.H, oklch.Alpha())
 
-		if InGamut(testColor) {
+		if InGamutOf(testColor, space) {
 			// Calculate perceptual distance
 			distance := math.Sqrt((testL-oklch.L)*(testL-oklch.L) + (testC-oklch.C)*(testC-oklch.C))
 			if distance < bestDistance {
@@ -142,7 +281,7 @@ func mapProject(c Color) Color {
 		testC := oklch.C
 		testColor := NewOKLCH(testL, testC, oklch.H, oklch.Alpha())
 
-		if InGamut(testColor) {
+		if InGamutOf(testColor, space) {
 			distance := math.Sqrt((testL-oklch.L)*(testL-oklch.L) + (testC-oklch.C)*(testC-oklch.C))
 			if distance < bestDistance {
 				bestDistance = distance
@@ -160,7 +299,7 @@ func mapProject(c Color) Color {
 			testC := oklch.C * (1 - ratioC)
 			testColor := NewOKLCH(testL, testC, oklch.H, oklch.Alpha())
 
-			if InGamut(testColor) {
+			if InGamutOf(testColor, space) {
 				distance := math.Sqrt((testL-oklch.L)*(testL-oklch.L) + (testC-oklch.C)*(testC-oklch.C))
 				if distance < bestDistance {
 					bestDistance = distance
@@ -173,12 +312,153 @@ func mapProject(c Color) Color {
 
 	// If still not found, fallback to preserve lightness
 	if bestDistance == math.MaxFloat64 {
-		return mapPreserveLightness(c)
+		return mapPreserveLightness(c, space)
 	}
 
 	return NewOKLCH(bestL, bestC, oklch.H, oklch.Alpha())
 }
 
+// mapCSS implements the CSS Color 4 gamut mapping algorithm.
+// See https://www.w3.org/TR/css-color-4/#binsearch
+func mapCSS(c Color, space Space) Color {
+	origin := ToOKLCH(c)
+	alpha := c.Alpha()
+
+	// Colors at or beyond the lightness extremes map to white or black
+	if origin.L >= 1 {
+		return mapClip(NewRGBA(1, 1, 1, alpha), space)
+	}
+	if origin.L <= 0 {
+		return mapClip(NewRGBA(0, 0, 0, alpha), space)
+	}
+
+	current := &OKLCH{L: origin.L, C: origin.C, H: origin.H, A_: alpha}
+	clipped := mapClip(current, space)
+	if DeltaEOK(clipped, current) < cssGamutJND {
+		return clipped
+	}
+
+	min := 0.0
+	max := origin.C
+	minInGamut := true
+
+	for max-min > cssGamutMinDE {
+		chroma := (min + max) / 2
+		current.C = chroma
+
+		if minInGamut && InGamutOf(current, space) {
+			min = chroma
+			continue
+		}
+
+		clipped = mapClip(current, space)
+		e := DeltaEOK(clipped, current)
+		if e < cssGamutJND {
+			if cssGamutJND-e < cssGamutMinDE {
+				return clipped
+			}
+			minInGamut = false
+			min = chroma
+		} else {
+			max = chroma
+		}
+	}
+
+	return clipped
+}
+
+// rayTracePasses is the number of ray casts performed by GamutRayTrace.
+const rayTracePasses = 4
+
+// mapRayTrace maps a color onto the gamut surface by casting rays in linear RGB.
+// Spaces that are not RGB spaces fall back to the CSS algorithm.
+func mapRayTrace(c Color, space Space) Color {
+	rgb, ok := space.(*rgbSpace)
+	if !ok {
+		return mapCSS(c, space)
+	}
+
+	origin := ToOKLCH(c)
+	alpha := c.Alpha()
+	if origin.L >= 1 {
+		return mapClip(NewRGBA(1, 1, 1, alpha), space)
+	}
+	if origin.L <= 0 {
+		return mapClip(NewRGBA(0, 0, 0, alpha), space)
+	}
+
+	lo, hi := rgb.linearRange()
+	anchor := linearIn(rgb, NewOKLCH(origin.L, 0, origin.H, alpha))
+	point := linearIn(rgb, c)
+
+	for i := 0; i < rayTracePasses; i++ {
+		if i > 0 {
+			// Restore the original lightness and hue, keeping the reduced chroma
+			x, y, z := rgb.linearToXYZ(point[0], point[1], point[2])
+			current := ToOKLCH(NewXYZ(x, y, z, alpha))
+			point = linearIn(rgb, NewOKLCH(origin.L, current.C, origin.H, alpha))
+		}
+
+		hit, ok := rayExitBox(anchor, point, lo, hi)
+		if !ok {
+			break
+		}
+		point = hit
+	}
+
+	x, y, z := rgb.linearToXYZ(point[0], point[1], point[2])
+	return mapClip(NewXYZ(x, y, z, alpha), space)
+}
+
+// linearIn converts a color to linear RGB in the given RGB space.
+func linearIn(rgb *rgbSpace, c Color) [3]float64 {
+	xyz := ToXYZ(c)
+	r, g, b := rgb.linearFromXYZ(xyz.X, xyz.Y, xyz.Z)
+	return [3]float64{r, g, b}
+}
+
+// rayExitBox finds where the ray from start (inside the box [lo, hi]^3) toward end
+// leaves the box. It returns false if end is already inside the box.
+func rayExitBox(start, end [3]float64, lo, hi float64) ([3]float64, bool) {
+	tFar := math.Inf(1)
+	for i := 0; i < 3; i++ {
+		d := end[i] - start[i]
+		if d == 0 {
+			continue
+		}
+		t1 := (lo - start[i]) / d
+		t2 := (hi - start[i]) / d
+		tFar = math.Min(tFar, math.Max(t1, t2))
+	}
+
+	if tFar >= 1 || tFar < 0 {
+		return end, false
+	}
+
+	var hit [3]float64
+	for i := 0; i < 3; i++ {
+		hit[i] = start[i] + (end[i]-start[i])*tFar
+	}
+	return hit, true
+}
+
+// mapEdgeSeeker reduces chroma to the precomputed gamut boundary of space.
+// The result is clipped to absorb the interpolation error of the table.
+func mapEdgeSeeker(c Color, space Space) Color {
+	oklch := ToOKLCH(c)
+	alpha := c.Alpha()
+	if oklch.L >= 1 {
+		return mapClip(NewRGBA(1, 1, 1, alpha), space)
+	}
+	if oklch.L <= 0 {
+		return mapClip(NewRGBA(0, 0, 0, alpha), space)
+	}
+
+	maxC := GamutBoundaryOf(space).MaxChroma(oklch.L, oklch.H)
+	mapped := NewOKLCH(oklch.L, math.Min(oklch.C, maxC), oklch.H, alpha)
+	return mapClip(mapped, space)
+}
+
 // ClipToGamut is a convenience function that clips a color to the sRGB gamut.
 // This is equivalent to MapToGamut(c, GamutClip).
 func ClipToGamut(c Color) Color {
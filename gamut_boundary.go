@@ -0,0 +1,139 @@
+package color
+
+import (
+	"math"
+	"reflect"
+	"sync"
+)
+
+// GamutBoundary is a precomputed table of the maximum in-gamut OKLCH chroma
+// for a color space, sampled on a regular lightness/hue grid.
+// Lookups interpolate bilinearly between cells, which is orders of magnitude
+// faster than searching for the boundary on every call.
+type GamutBoundary struct {
+	space  Space
+	lSteps int       // Number of lightness intervals (lSteps+1 samples from 0 to 1)
+	hSteps int       // Number of hue samples (wrapping around 360°)
+	chroma []float64 // Max chroma, indexed [l*hSteps + h]
+}
+
+// Default resolution of boundaries built by GamutBoundaryOf.
+const (
+	defaultBoundaryLSteps = 100 // 0.01 lightness per cell
+	defaultBoundaryHSteps = 180 // 2° hue per cell
+)
+
+// gamutBoundaryCache holds the boundaries built by GamutBoundaryOf, keyed by
+// space (see boundaryKey).
+var gamutBoundaryCache sync.Map
+
+// NewGamutBoundary computes the gamut boundary of a space at the given resolution.
+// lSteps is the number of lightness intervals and hSteps the number of hue samples.
+// Higher resolutions are more accurate but take longer to build.
+//
+// Example:
+//   boundary := color.NewGamutBoundary(color.DisplayP3Space, 200, 360)
+//   maxC := boundary.MaxChroma(0.7, 150)
+func NewGamutBoundary(space Space, lSteps, hSteps int) *GamutBoundary {
+	if lSteps < 1 {
+		lSteps = 1
+	}
+	if hSteps < 1 {
+		hSteps = 1
+	}
+
+	b := &GamutBoundary{
+		space:  space,
+		lSteps: lSteps,
+		hSteps: hSteps,
+		chroma: make([]float64, (lSteps+1)*hSteps),
+	}
+
+	for li := 0; li <= lSteps; li++ {
+		l := float64(li) / float64(lSteps)
+		for hi := 0; hi < hSteps; hi++ {
+			h := float64(hi) * 360 / float64(hSteps)
+			b.chroma[li*hSteps+hi] = maxChromaOf(l, h, space)
+		}
+	}
+
+	return b
+}
+
+// GamutBoundaryOf returns the cached gamut boundary of a space, building it on first use.
+// Spaces are compared as values, so two spaces from NewRGBSpace with the same
+// name get their own boundaries; only a Space type that is not comparable is
+// identified by Name. It is safe for concurrent use.
+func GamutBoundaryOf(space Space) *GamutBoundary {
+	key := boundaryKey(space)
+	if b, ok := gamutBoundaryCache.Load(key); ok {
+		return b.(*GamutBoundary)
+	}
+	b, _ := gamutBoundaryCache.LoadOrStore(key, NewGamutBoundary(space, defaultBoundaryLSteps, defaultBoundaryHSteps))
+	return b.(*GamutBoundary)
+}
+
+// boundaryKey returns the gamutBoundaryCache key of a space: the space itself,
+// or its name if it cannot be a map key.
+func boundaryKey(space Space) any {
+	if reflect.ValueOf(space).Comparable() {
+		return space
+	}
+	return space.Name()
+}
+
+// Space returns the color space this boundary was computed for.
+func (b *GamutBoundary) Space() Space {
+	return b.space
+}
+
+// MaxChroma returns the interpolated maximum in-gamut OKLCH chroma for a lightness and hue.
+func (b *GamutBoundary) MaxChroma(l, h float64) float64 {
+	l = clamp01(l)
+	h = normalizeHue(h)
+
+	lPos := l * float64(b.lSteps)
+	l0 := int(lPos)
+	if l0 >= b.lSteps {
+		l0 = b.lSteps - 1
+	}
+	lt := lPos - float64(l0)
+
+	hPos := h * float64(b.hSteps) / 360
+	h0 := int(hPos) % b.hSteps
+	h1 := (h0 + 1) % b.hSteps
+	ht := hPos - math.Floor(hPos)
+
+	c00 := b.chroma[l0*b.hSteps+h0]
+	c01 := b.chroma[l0*b.hSteps+h1]
+	c10 := b.chroma[(l0+1)*b.hSteps+h0]
+	c11 := b.chroma[(l0+1)*b.hSteps+h1]
+
+	c0 := c00*(1-ht) + c01*ht
+	c1 := c10*(1-ht) + c11*ht
+	return c0*(1-lt) + c1*lt
+}
+
+// inGamutChroma returns MaxChroma lowered, where needed, to the boundary itself.
+// The boundary is concave between samples, so the interpolated chroma can lie
+// slightly outside the gamut; a short search below it finds the in-gamut limit.
+func (b *GamutBoundary) inGamutChroma(l, h float64) float64 {
+	l = clamp01(l)
+	hi := b.MaxChroma(l, h)
+	if InGamutOf(NewOKLCH(l, hi, h, 1), b.space) {
+		return hi
+	}
+	lo := hi * 0.9
+	if !InGamutOf(NewOKLCH(l, lo, h, 1), b.space) {
+		return maxChromaOf(l, h, b.space)
+	}
+	for i := 0; i < 12; i++ {
+		mid := (lo + hi) / 2
+		if InGamutOf(NewOKLCH(l, mid, h, 1), b.space) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
@@ -0,0 +1,101 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestGamutBoundaryMatchesSearch(t *testing.T) {
+	boundary := GamutBoundaryOf(SRGBSpace)
+
+	// Hues avoid the sharp blue cusp near 264°, where interpolation is least accurate
+	for _, l := range []float64{0.2, 0.45, 0.7, 0.9} {
+		for _, h := range []float64{0, 29, 90, 145, 200, 245, 330} {
+			want := maxChromaOf(l, h, SRGBSpace)
+			got := boundary.MaxChroma(l, h)
+			if abs(got-want) > 0.01 {
+				t.Errorf("MaxChroma(%.2f, %.0f) = %f, want ~%f", l, h, got, want)
+			}
+		}
+	}
+}
+
+func TestGamutBoundaryExtremes(t *testing.T) {
+	boundary := GamutBoundaryOf(SRGBSpace)
+
+	if c := boundary.MaxChroma(0, 120); c != 0 {
+		t.Errorf("MaxChroma at L=0 = %f, want 0", c)
+	}
+	if c := boundary.MaxChroma(1, 120); c != 0 {
+		t.Errorf("MaxChroma at L=1 = %f, want 0", c)
+	}
+
+	// Hue wraps around 360°
+	if a, b := boundary.MaxChroma(0.6, 359.5), boundary.MaxChroma(0.6, -0.5); abs(a-b) > 1e-12 {
+		t.Errorf("MaxChroma(359.5) = %f, MaxChroma(-0.5) = %f, want equal", a, b)
+	}
+}
+
+func TestGamutBoundaryCached(t *testing.T) {
+	if GamutBoundaryOf(DisplayP3Space) != GamutBoundaryOf(DisplayP3Space) {
+		t.Error("GamutBoundaryOf should return the cached boundary")
+	}
+	if GamutBoundaryOf(DisplayP3Space).Space() != DisplayP3Space {
+		t.Error("Boundary reports the wrong space")
+	}
+}
+
+func TestGamutBoundarySameName(t *testing.T) {
+	// Custom spaces sharing a name must not share a boundary
+	narrow, err := NewRGBSpace("same-name", srgbPrimaries, WhiteD65, TransferSRGB, bradfordAdaptation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wide, err := NewRGBSpace("same-name", displayP3Primaries, WhiteD65, TransferSRGB, bradfordAdaptation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if GamutBoundaryOf(narrow) == GamutBoundaryOf(wide) {
+		t.Fatal("Spaces with the same name share a boundary")
+	}
+	if n, w := GamutBoundaryOf(narrow).MaxChroma(0.6, 145), GamutBoundaryOf(wide).MaxChroma(0.6, 145); w <= n {
+		t.Errorf("Wide space max chroma %f not above narrow %f", w, n)
+	}
+}
+
+// sliceSpace is a non-pointer Space with a slice field, so it cannot be a map key.
+type sliceSpace struct {
+	channels []string
+}
+
+func (s sliceSpace) Name() string                        { return "test-slice-space" }
+func (s sliceSpace) Channels() int                       { return len(s.channels) }
+func (s sliceSpace) ChannelNames() []string              { return s.channels }
+func (s sliceSpace) ToXYZ(c []float64) (x, y, z float64) { return SRGBLinearSpace.ToXYZ(c) }
+func (s sliceSpace) FromXYZ(x, y, z float64) []float64   { return SRGBLinearSpace.FromXYZ(x, y, z) }
+
+func TestGamutBoundaryNonComparableSpace(t *testing.T) {
+	space := sliceSpace{channels: []string{"r", "g", "b"}}
+	if GamutBoundaryOf(space) != GamutBoundaryOf(space) {
+		t.Error("GamutBoundaryOf should return the cached boundary")
+	}
+}
+
+func TestGamutBoundaryWideGamut(t *testing.T) {
+	// Display P3 holds more chroma than sRGB everywhere
+	srgb := GamutBoundaryOf(SRGBSpace)
+	p3 := GamutBoundaryOf(DisplayP3Space)
+
+	for _, h := range []float64{30, 145, 265} {
+		if p3.MaxChroma(0.6, h) <= srgb.MaxChroma(0.6, h) {
+			t.Errorf("Hue %.0f: P3 max chroma %f not above sRGB %f", h, p3.MaxChroma(0.6, h), srgb.MaxChroma(0.6, h))
+		}
+	}
+}
+
+func TestNewGamutBoundaryResolution(t *testing.T) {
+	coarse := NewGamutBoundary(SRGBSpace, 10, 36)
+	want := maxChromaOf(0.5, 100, SRGBSpace)
+	if got := coarse.MaxChroma(0.5, 100); abs(got-want) > 1e-9 {
+		t.Errorf("MaxChroma on a grid point = %f, want %f", got, want)
+	}
+}
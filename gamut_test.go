@@ -31,10 +31,41 @@ func TestInGamut(t *testing.T) {
 			expected: true,
 		},
 		{
-			name:     "Vivid color that stays in gamut",
+			// Reported in gamut before the unclamped InGamut; its red channel is negative
+			name:     "Vivid color just outside gamut",
 			color:    NewOKLCH(0.7, 0.2, 150, 1.0),
+			expected: false,
+		},
+		{
+			name:     "Vivid color that stays in gamut",
+			color:    NewOKLCH(0.7, 0.1, 150, 1.0),
 			expected: true,
 		},
+		{
+			name:     "High chroma OKLCH out of gamut",
+			color:    NewOKLCH(0.7, 0.4, 30, 1.0),
+			expected: false,
+		},
+		{
+			name:     "High chroma OKLAB out of gamut",
+			color:    NewOKLAB(0.7, 0.3, 0.2, 1.0),
+			expected: false,
+		},
+		{
+			name:     "LAB outside sRGB",
+			color:    NewLAB(50, 100, 0, 1.0),
+			expected: false,
+		},
+		{
+			name:     "XYZ outside sRGB",
+			color:    NewXYZ(0.2, 0.6, 0.05, 1.0),
+			expected: false,
+		},
+		{
+			name:     "Display P3 red outside sRGB",
+			color:    NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1.0),
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +128,25 @@ func TestMapToGamutPreserveChroma(t *testing.T) {
 		t.Error("MapToGamut(GamutPreserveChroma) produced out-of-gamut color")
 	}
 
+	// Chroma 0.35 is beyond sRGB at this hue, so the most vivid color is the cusp
+	mappedOKLCH := ToOKLCH(mapped)
+	_, cuspC := findCusp(150, SRGBSpace)
+
+	if abs(cuspC-mappedOKLCH.C) > 0.01 || abs(mappedOKLCH.H-150) > 1 {
+		t.Errorf("Expected the cusp chroma %f at hue 150, got %f at hue %f", cuspC, mappedOKLCH.C, mappedOKLCH.H)
+	}
+}
+
+func TestMapToGamutPreserveChromaReachable(t *testing.T) {
+	// Create an out-of-gamut color whose chroma is reachable at a lower lightness
+	vivid := NewOKLCH(0.95, 0.2, 150, 1.0)
+
+	mapped := MapToGamut(vivid, GamutPreserveChroma)
+
+	if !InGamut(mapped) {
+		t.Error("MapToGamut(GamutPreserveChroma) produced out-of-gamut color")
+	}
+
 	// Chroma should be approximately preserved
 	originalOKLCH := ToOKLCH(vivid)
 	mappedOKLCH := ToOKLCH(mapped)
@@ -211,3 +261,371 @@ func BenchmarkMapToGamutProject(b *testing.B) {
 		MapToGamut(c, GamutProject)
 	}
 }
+
+func TestUnclampedRGBA(t *testing.T) {
+	// oklch(0.7 0.4 30) is far outside sRGB; RGBA clamps, UnclampedRGBA must not
+	vivid := NewOKLCH(0.7, 0.4, 30, 1.0)
+
+	r, g, b, _ := UnclampedRGBA(vivid)
+	if r <= 1 {
+		t.Errorf("UnclampedRGBA red = %f, want > 1", r)
+	}
+	if g >= 0 && b >= 0 {
+		t.Errorf("UnclampedRGBA = (%f, %f, %f), want a negative component", r, g, b)
+	}
+
+	cr, cg, cb, _ := vivid.RGBA()
+	if cr != clamp01(r) || cg != clamp01(g) || cb != clamp01(b) {
+		t.Errorf("RGBA() = (%f, %f, %f), want clamped UnclampedRGBA (%f, %f, %f)", cr, cg, cb, r, g, b)
+	}
+
+	// In-gamut colors agree with RGBA
+	orange := RGB(1, 0.5, 0.25)
+	r, g, b, _ = UnclampedRGBA(orange)
+	if abs(r-1) > 1e-9 || abs(g-0.5) > 1e-9 || abs(b-0.25) > 1e-9 {
+		t.Errorf("UnclampedRGBA(orange) = (%f, %f, %f), want (1, 0.5, 0.25)", r, g, b)
+	}
+}
+
+func TestUnclampedRoundTrip(t *testing.T) {
+	// Converting an out-of-gamut color must not lose chroma through XYZ or OKLAB
+	vivid := NewOKLCH(0.7, 0.4, 30, 1.0)
+
+	oklch := ToOKLCH(vivid)
+	if abs(oklch.C-0.4) > 1e-6 || abs(oklch.H-30) > 1e-4 {
+		t.Errorf("ToOKLCH round trip = (%f, %f, %f), want (0.7, 0.4, 30)", oklch.L, oklch.C, oklch.H)
+	}
+
+	back := ToOKLCH(ToXYZ(vivid))
+	if abs(back.C-0.4) > 1e-4 {
+		t.Errorf("XYZ round trip chroma = %f, want 0.4", back.C)
+	}
+}
+
+func TestMapToGamutOutOfGamut(t *testing.T) {
+	vivid := NewOKLCH(0.7, 0.4, 30, 1.0)
+
+	for _, strategy := range []GamutMapping{GamutClip, GamutPreserveLightness, GamutPreserveChroma, GamutProject} {
+		mapped := MapToGamut(vivid, strategy)
+		if mapped == Color(vivid) {
+			t.Errorf("Strategy %v: out-of-gamut color returned unchanged", strategy)
+		}
+		if !InGamut(mapped) {
+			t.Errorf("Strategy %v: mapped color not in gamut", strategy)
+		}
+	}
+
+	// Preserving lightness must keep the hue instead of shifting it like clipping
+	mapped := ToOKLCH(MapToGamut(vivid, GamutPreserveLightness))
+	if abs(mapped.H-30) > 1 {
+		t.Errorf("GamutPreserveLightness hue = %f, want ~30", mapped.H)
+	}
+	if mapped.C >= 0.4 {
+		t.Errorf("GamutPreserveLightness chroma = %f, want < 0.4", mapped.C)
+	}
+}
+
+func TestEstimateMaxChroma(t *testing.T) {
+	// sRGB red sits on the gamut boundary, so its chroma is the maximum for its L and H
+	red := ToOKLCH(RGB(1, 0, 0))
+	maxC := estimateMaxChroma(red.L, red.H)
+	if abs(maxC-red.C) > 0.005 {
+		t.Errorf("estimateMaxChroma(red) = %f, want ~%f", maxC, red.C)
+	}
+}
+
+func TestEstimateMaxChromaInGamut(t *testing.T) {
+	// Between table samples the boundary is concave, so the estimate must be
+	// pulled back to stay in gamut
+	for l := 0.02; l < 1; l += 0.03 {
+		for h := 0.5; h < 360; h += 7 {
+			if c := estimateMaxChroma(l, h); !InGamut(NewOKLCH(l, c, h, 1)) {
+				t.Errorf("estimateMaxChroma(%.2f, %.1f) = %f is out of gamut", l, h, c)
+			}
+		}
+	}
+}
+
+func TestConvertToWithMappingOutOfGamut(t *testing.T) {
+	p3Red := NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1.0).(*spaceColor)
+
+	mapped := p3Red.ConvertToWithMapping(SRGBSpace, GamutPreserveLightness)
+	for i, v := range mapped.Channels() {
+		if v < -gamutEpsilon || v > 1+gamutEpsilon {
+			t.Errorf("Channel %d = %f, want in [0, 1]", i, v)
+		}
+	}
+}
+
+func TestMapToGamutCSS(t *testing.T) {
+	tests := []struct {
+		name  string
+		color Color
+	}{
+		{"oklch red", NewOKLCH(0.7, 0.4, 30, 1.0)},
+		{"oklch green", NewOKLCH(0.8, 0.35, 145, 1.0)},
+		{"oklch blue", NewOKLCH(0.45, 0.35, 265, 1.0)},
+		{"lab magenta", NewLAB(60, 110, -80, 1.0)},
+		{"display-p3 green", NewSpaceColor(DisplayP3Space, []float64{0, 1, 0}, 1.0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := ToOKLCH(tt.color)
+			mapped := MapToGamut(tt.color, GamutCSS)
+
+			if !InGamut(mapped) {
+				t.Fatal("GamutCSS produced out-of-gamut color")
+			}
+
+			// The result is the clip of a chroma-reduced color at the original
+			// lightness and hue, within one JND of that color
+			result := ToOKLCH(mapped)
+			if result.C > origin.C {
+				t.Errorf("Chroma increased: %f -> %f", origin.C, result.C)
+			}
+			reduced := NewOKLCH(origin.L, result.C, origin.H, 1.0)
+			if abs(result.L-origin.L) > 0.05 {
+				t.Errorf("Lightness drifted: %f -> %f", origin.L, result.L)
+			}
+			if DeltaEOK(mapped, reduced) > cssGamutJND+0.01 {
+				t.Errorf("DeltaEOK from chroma-reduced color = %f, want < JND", DeltaEOK(mapped, reduced))
+			}
+		})
+	}
+}
+
+func TestMapToGamutCSSSpecCases(t *testing.T) {
+	// Steps 3 and 4 of the algorithm: lightness extremes map to white and black
+	white := MapToGamut(NewOKLCH(1, 0.2, 30, 0.5), GamutCSS)
+	r, g, b, a := white.RGBA()
+	if r != 1 || g != 1 || b != 1 || a != 0.5 {
+		t.Errorf("L=1 mapped to (%f, %f, %f, %f), want white with alpha 0.5", r, g, b, a)
+	}
+
+	black := MapToGamut(NewOKLAB(0, 0.1, 0.1, 1), GamutCSS)
+	r, g, b, _ = black.RGBA()
+	if r != 0 || g != 0 || b != 0 {
+		t.Errorf("L=0 mapped to (%f, %f, %f), want black", r, g, b)
+	}
+
+	// In-gamut colors are returned unchanged
+	orange := RGB(1, 0.5, 0)
+	if MapToGamut(orange, GamutCSS) != Color(orange) {
+		t.Error("In-gamut color was modified")
+	}
+
+	// Colors that clip within one JND are simply clipped
+	nearRed := NewRGBA(1, 0, 0, 1)
+	nearRed.R = 1.01
+	mapped := MapToGamut(nearRed, GamutCSS)
+	r, g, b, _ = mapped.RGBA()
+	if r != 1 || g != 0 || b != 0 {
+		t.Errorf("Near-gamut red mapped to (%f, %f, %f), want clipped (1, 0, 0)", r, g, b)
+	}
+
+	// Out-of-gamut colors, against the algorithm of Color.js
+	// toGamut({method: "css"}) run with Color.js's sRGB, Display P3 and OKLab
+	// matrices. The binary search stops within its epsilon of the JND, so the
+	// last digits depend on the D65 white each library uses.
+	references := []struct {
+		name  string
+		color Color
+		want  [3]float64
+	}{
+		{"oklch(0.7 0.4 30)", NewOKLCH(0.7, 0.4, 30, 1), [3]float64{1, 0.345135, 0.264575}},
+		{"oklch(0.8 0.35 145)", NewOKLCH(0.8, 0.35, 145, 1), [3]float64{0, 0.913286, 0.029212}},
+		{"oklch(0.45 0.35 265)", NewOKLCH(0.45, 0.35, 265, 1), [3]float64{0.071801, 0, 1}},
+		{"oklch(0.9 0.3 100)", NewOKLCH(0.9, 0.3, 100, 1), [3]float64{0.998987, 0.873470, 0}},
+		{"color(display-p3 0 1 0)", NewSpaceColor(DisplayP3Space, []float64{0, 1, 0}, 1), [3]float64{0, 0.985764, 0.159742}},
+		{"color(display-p3 1 0 0)", NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1), [3]float64{1, 0.044570, 0.045932}},
+	}
+	for _, tt := range references {
+		r, g, b, _ := MapToGamut(tt.color, GamutCSS).RGBA()
+		for i, v := range [3]float64{r, g, b} {
+			if !floatNear(v, tt.want[i], 0.002) {
+				t.Errorf("%s mapped to (%f, %f, %f), want %v", tt.name, r, g, b, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestInGamutOf(t *testing.T) {
+	p3Red := NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1.0)
+	proPhotoGreen := NewSpaceColor(ProPhotoRGBSpace, []float64{0, 1, 0}, 1.0)
+
+	tests := []struct {
+		name     string
+		color    Color
+		space    Space
+		expected bool
+	}{
+		{"sRGB red in sRGB", RGB(1, 0, 0), SRGBSpace, true},
+		{"sRGB red in Display P3", RGB(1, 0, 0), DisplayP3Space, true},
+		{"sRGB red in Rec.2020", RGB(1, 0, 0), Rec2020Space, true},
+		{"sRGB red in ProPhoto", RGB(1, 0, 0), ProPhotoRGBSpace, true},
+		{"sRGB red in DCI-P3", RGB(1, 0, 0), DCIP3Space, true},
+		{"sRGB red in S-Log3", RGB(1, 0, 0), SLog3Space, true},
+		{"P3 red in sRGB", p3Red, SRGBSpace, false},
+		{"P3 red in Display P3", p3Red, DisplayP3Space, true},
+		{"P3 red just outside Rec.2020", p3Red, Rec2020Space, false},
+		{"P3 red in A98", p3Red, A98RGBSpace, false},
+		{"ProPhoto green in Rec.2020", proPhotoGreen, Rec2020Space, false},
+		{"ProPhoto green in ProPhoto", proPhotoGreen, ProPhotoRGBSpace, true},
+		{"Vivid OKLCH in Rec.2020", NewOKLCH(0.7, 0.4, 30, 1.0), Rec2020Space, false},
+		{"Anything in OKLCH", NewOKLCH(0.7, 0.4, 30, 1.0), OKLCHSpace, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InGamutOf(tt.color, tt.space); got != tt.expected {
+				t.Errorf("InGamutOf(%s) = %v, want %v", tt.space.Name(), got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapToGamutOf(t *testing.T) {
+	vivid := NewOKLCH(0.7, 0.4, 30, 1.0)
+	spaces := []Space{SRGBSpace, DisplayP3Space, A98RGBSpace, ProPhotoRGBSpace, Rec2020Space, DCIP3Space}
+	strategies := []GamutMapping{GamutClip, GamutPreserveChroma, GamutPreserveLightness, GamutProject, GamutCSS}
+
+	for _, space := range spaces {
+		for _, strategy := range strategies {
+			mapped := MapToGamutOf(vivid, space, strategy)
+
+			if mapped.Space() != space {
+				t.Errorf("%s, strategy %v: result in %s, want %s", space.Name(), strategy, mapped.Space().Name(), space.Name())
+			}
+			if !InGamutOf(mapped, space) {
+				t.Errorf("%s, strategy %v: result not in gamut", space.Name(), strategy)
+			}
+			for i, v := range mapped.Channels() {
+				if v < -0.001 || v > 1.001 {
+					t.Errorf("%s, strategy %v: channel %d = %f, want in [0, 1]", space.Name(), strategy, i, v)
+				}
+			}
+		}
+	}
+}
+
+func TestMapToGamutOfKeepsWideGamut(t *testing.T) {
+	// A P3 color that is outside sRGB but inside P3 must not be crushed to sRGB
+	p3Green := NewSpaceColor(DisplayP3Space, []float64{0.2, 0.9, 0.3}, 1.0)
+
+	mapped := MapToGamutOf(p3Green, DisplayP3Space, GamutCSS)
+	channels := mapped.Channels()
+	if abs(channels[0]-0.2) > 1e-6 || abs(channels[1]-0.9) > 1e-6 || abs(channels[2]-0.3) > 1e-6 {
+		t.Errorf("In-gamut P3 color changed: %v", channels)
+	}
+
+	// Mapping Rec.2020 green into P3 stays outside sRGB
+	rec2020Green := NewSpaceColor(Rec2020Space, []float64{0, 1, 0}, 1.0).(*spaceColor)
+	p3 := rec2020Green.ConvertToWithMapping(DisplayP3Space, GamutCSS)
+	if !InGamutOf(p3, DisplayP3Space) {
+		t.Error("ConvertToWithMapping result not in Display P3 gamut")
+	}
+	if InGamut(p3) {
+		t.Error("ConvertToWithMapping crushed the color into sRGB")
+	}
+}
+
+func TestMapToGamutOfUnbounded(t *testing.T) {
+	vivid := NewOKLCH(0.7, 0.4, 30, 1.0)
+	mapped := MapToGamutOf(vivid, OKLCHSpace, GamutCSS)
+
+	channels := mapped.Channels()
+	if abs(channels[1]-0.4) > 1e-4 {
+		t.Errorf("Unbounded space changed chroma: %v", channels)
+	}
+}
+
+func TestMapToGamutOfRegisteredSpace(t *testing.T) {
+	custom := &rgbSpace{
+		name:                "test-p3-linear",
+		xyzToRGBMatrix:      DisplayP3Space.(*rgbSpace).xyzToRGBMatrix,
+		rgbToXYZMatrix:      DisplayP3Space.(*rgbSpace).rgbToXYZMatrix,
+		transferFunc:        linearTransfer,
+		inverseTransferFunc: linearInverseTransfer,
+		whitePoint:          WhiteD65,
+	}
+	RegisterSpace("test-p3-linear", custom)
+	defer UnregisterSpace("test-p3-linear")
+
+	space, _ := GetSpace("test-p3-linear")
+	p3Red := NewSpaceColor(DisplayP3Space, []float64{1, 0, 0}, 1.0)
+	if !InGamutOf(p3Red, space) {
+		t.Error("P3 red should be inside linear P3")
+	}
+
+	mapped := MapToGamutOf(NewOKLCH(0.7, 0.4, 30, 1.0), space, GamutPreserveLightness)
+	if !InGamutOf(mapped, space) {
+		t.Error("Mapped color not in custom space gamut")
+	}
+}
+
+func TestMapToGamutRayTrace(t *testing.T) {
+	colors := []Color{
+		NewOKLCH(0.7, 0.4, 30, 1.0),
+		NewOKLCH(0.8, 0.35, 145, 1.0),
+		NewOKLCH(0.45, 0.35, 265, 1.0),
+		NewOKLCH(0.95, 0.3, 100, 1.0),
+		NewOKLCH(0.1, 0.3, 300, 1.0),
+	}
+
+	for i, c := range colors {
+		origin := ToOKLCH(c)
+		for _, space := range []Space{SRGBSpace, DisplayP3Space, ProPhotoRGBSpace} {
+			mapped := MapToGamutOf(c, space, GamutRayTrace)
+			if !InGamutOf(mapped, space) {
+				t.Errorf("Color %d, %s: result not in gamut", i, space.Name())
+			}
+
+			result := ToOKLCH(mapped)
+			if abs(result.L-origin.L) > 0.03 {
+				t.Errorf("Color %d, %s: lightness %f -> %f", i, space.Name(), origin.L, result.L)
+			}
+			if result.C > 0.02 {
+				hueDiff := abs(result.H - origin.H)
+				if hueDiff > 180 {
+					hueDiff = 360 - hueDiff
+				}
+				if hueDiff > 3 {
+					t.Errorf("Color %d, %s: hue %f -> %f", i, space.Name(), origin.H, result.H)
+				}
+			}
+		}
+	}
+}
+
+func TestMapToGamutRayTraceNearCSS(t *testing.T) {
+	// Ray tracing should land close to the CSS reference algorithm
+	for _, h := range []float64{0, 60, 120, 180, 240, 300} {
+		c := NewOKLCH(0.65, 0.4, h, 1.0)
+		css := MapToGamut(c, GamutCSS)
+		ray := MapToGamut(c, GamutRayTrace)
+		if d := DeltaEOK(css, ray); d > 0.03 {
+			t.Errorf("Hue %.0f: DeltaEOK(css, raytrace) = %f", h, d)
+		}
+	}
+}
+
+func TestMapToGamutEdgeSeeker(t *testing.T) {
+	for _, h := range []float64{0, 60, 120, 180, 240, 300} {
+		c := NewOKLCH(0.65, 0.4, h, 0.8)
+		mapped := MapToGamut(c, GamutEdgeSeeker)
+		if !InGamut(mapped) {
+			t.Errorf("Hue %.0f: result not in gamut", h)
+		}
+		if mapped.Alpha() != 0.8 {
+			t.Errorf("Hue %.0f: alpha = %f, want 0.8", h, mapped.Alpha())
+		}
+
+		preserved := ToOKLCH(MapToGamut(c, GamutPreserveLightness))
+		result := ToOKLCH(mapped)
+		if abs(result.C-preserved.C) > 0.015 {
+			t.Errorf("Hue %.0f: chroma %f, want ~%f", h, result.C, preserved.C)
+		}
+	}
+}
@@ -60,6 +60,22 @@ const (
 	GradientLCH
 	// GradientOKLCH interpolates in OKLCH space (perceptually uniform, recommended)
 	GradientOKLCH
+	// GradientSRGBLinear interpolates in linear-light sRGB (physically correct light mixing)
+	GradientSRGBLinear
+	// GradientDisplayP3 interpolates in gamma-encoded Display P3
+	GradientDisplayP3
+	// GradientXYZD50 interpolates in CIE XYZ relative to D50
+	GradientXYZD50
+	// GradientXYZD65 interpolates in CIE XYZ relative to D65
+	GradientXYZD65
+	// GradientHWB interpolates in HWB space
+	GradientHWB
+	// GradientA98RGB interpolates in gamma-encoded Adobe RGB (1998)
+	GradientA98RGB
+	// GradientProPhotoRGB interpolates in gamma-encoded ProPhoto RGB
+	GradientProPhotoRGB
+	// GradientRec2020 interpolates in gamma-encoded Rec. 2020
+	GradientRec2020
 )
 
 // HueInterpolation specifies how to interpolate hue values in cylindrical color spaces.
@@ -77,78 +93,219 @@ const (
 )
 
 // MixInSpace mixes two colors in the specified color space.
+// Hues in cylindrical spaces take the shorter path around the color wheel.
 func MixInSpace(c1, c2 Color, weight float64, space GradientSpace) Color {
-	weight = clamp01(weight)
+	return MixInSpaceWithHue(c1, c2, weight, space, HueShorter)
+}
+
+// MixInSpaceWithHue mixes two colors in the specified color space, interpolating
+// hue with the given method. The method only affects cylindrical spaces
+// (HSL, HWB, LCH, OKLCH).
+//
+// Example:
+//
+//	// Go the long way around the color wheel, through green
+//	c := color.MixInSpaceWithHue(red, blue, 0.5, color.GradientOKLCH, color.HueLonger)
+func MixInSpaceWithHue(c1, c2 Color, weight float64, space GradientSpace, hue HueInterpolation) Color {
+	return mixInSpace(c1, c2, weight, space, mixOptions{hue: hue})
+}
+
+// mixOptions controls how mixInSpace interpolates.
+type mixOptions struct {
+	hue           HueInterpolation
+	premultiplied bool // Interpolate with premultiplied alpha, as CSS color-mix() does
+}
+
+// channelWeight returns the weight for the non-hue channels of a mix.
+// With premultiplied alpha the more opaque color contributes proportionally more,
+// which is the same as premultiplying, interpolating and dividing by the mixed alpha.
+func (o mixOptions) channelWeight(a1, a2, weight float64) float64 {
+	if !o.premultiplied {
+		return weight
+	}
+	total := a1*(1-weight) + a2*weight
+	if total == 0 {
+		return weight
+	}
+	return a2 * weight / total
+}
+
+// gradientModel describes the channels of a GradientSpace for interpolation.
+type gradientModel struct {
+	kinds [3]componentKind                         // Channel kinds, for carrying missing components forward
+	hue   int                                      // Index of the hue channel, or -1
+	to    func(c Color) [3]float64                 // Channels of a color in this space
+	from  func(ch [3]float64, alpha float64) Color // Color from interpolated channels
+}
+
+// gradientModelOf returns the interpolation model of a GradientSpace.
+func gradientModelOf(space GradientSpace) gradientModel {
+	rgbKinds := [3]componentKind{kindRed, kindGreen, kindBlue}
+	labKinds := [3]componentKind{kindLightness, kindOpponentA, kindOpponentB}
+	lchKinds := [3]componentKind{kindLightness, kindColorfulness, kindHue}
 
 	switch space {
 	case GradientRGB:
-		return Mix(c1, c2, weight)
+		return gradientModel{
+			kinds: rgbKinds, hue: -1,
+			// Out-of-gamut colors are mixed unclamped and the result clipped, as CSS does
+			to: func(c Color) [3]float64 {
+				r, g, b, _ := UnclampedRGBA(c)
+				return [3]float64{r, g, b}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewRGBA(ch[0], ch[1], ch[2], alpha) },
+		}
 	case GradientHSL:
-		return mixHSL(c1, c2, weight)
+		return gradientModel{
+			kinds: [3]componentKind{kindHue, kindColorfulness, kindLightness}, hue: 0,
+			to: func(c Color) [3]float64 {
+				hsl := ToHSL(c)
+				return [3]float64{hsl.H, hsl.S, hsl.L}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewHSL(ch[0], ch[1], ch[2], alpha) },
+		}
+	case GradientHWB:
+		return gradientModel{
+			kinds: [3]componentKind{kindHue, kindWhiteness, kindBlackness}, hue: 0,
+			to: func(c Color) [3]float64 {
+				hwb := ToHWB(c)
+				return [3]float64{hwb.H, hwb.W, hwb.B}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewHWB(ch[0], ch[1], ch[2], alpha) },
+		}
 	case GradientLAB:
-		return mixLAB(c1, c2, weight)
+		return gradientModel{
+			kinds: labKinds, hue: -1,
+			to: func(c Color) [3]float64 {
+				lab := ToLAB(c)
+				return [3]float64{lab.L, lab.A, lab.B}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewLAB(ch[0], ch[1], ch[2], alpha) },
+		}
 	case GradientOKLAB:
-		return mixOKLAB(c1, c2, weight)
+		return gradientModel{
+			kinds: labKinds, hue: -1,
+			to: func(c Color) [3]float64 {
+				lab := ToOKLAB(c)
+				return [3]float64{lab.L, lab.A, lab.B}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewOKLAB(ch[0], ch[1], ch[2], alpha) },
+		}
 	case GradientLCH:
-		return mixLCH(c1, c2, weight)
-	case GradientOKLCH:
-		return MixOKLCH(c1, c2, weight)
+		return gradientModel{
+			kinds: lchKinds, hue: 2,
+			to: func(c Color) [3]float64 {
+				lch := ToLCH(c)
+				return [3]float64{lch.L, lch.C, lch.H}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewLCH(ch[0], ch[1], ch[2], alpha) },
+		}
+	case GradientSRGBLinear:
+		return spaceGradientModel(SRGBLinearSpace)
+	case GradientDisplayP3:
+		return spaceGradientModel(DisplayP3Space)
+	case GradientA98RGB:
+		return spaceGradientModel(A98RGBSpace)
+	case GradientProPhotoRGB:
+		return spaceGradientModel(ProPhotoRGBSpace)
+	case GradientRec2020:
+		return spaceGradientModel(Rec2020Space)
+	case GradientXYZD50:
+		// Bradford adaptation is linear, so this matches XYZ D65 up to rounding;
+		// the result is returned relative to D65 like every other XYZ in this package.
+		return gradientModel{
+			kinds: rgbKinds, hue: -1,
+			to: func(c Color) [3]float64 {
+				xyz := ToXYZ(c)
+				x, y, z := AdaptD65ToD50(xyz.X, xyz.Y, xyz.Z)
+				return [3]float64{x, y, z}
+			},
+			from: func(ch [3]float64, alpha float64) Color {
+				x, y, z := AdaptD50ToD65(ch[0], ch[1], ch[2])
+				return NewXYZ(x, y, z, alpha)
+			},
+		}
+	case GradientXYZD65:
+		return gradientModel{
+			kinds: rgbKinds, hue: -1,
+			to: func(c Color) [3]float64 {
+				xyz := ToXYZ(c)
+				return [3]float64{xyz.X, xyz.Y, xyz.Z}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewXYZ(ch[0], ch[1], ch[2], alpha) },
+		}
 	default:
-		return MixOKLCH(c1, c2, weight) // Default to OKLCH
+		return gradientModel{
+			kinds: lchKinds, hue: 2,
+			to: func(c Color) [3]float64 {
+				lch := ToOKLCH(c)
+				return [3]float64{lch.L, lch.C, lch.H}
+			},
+			from: func(ch [3]float64, alpha float64) Color { return NewOKLCH(ch[0], ch[1], ch[2], alpha) },
+		}
 	}
 }
 
-// mixHSL mixes colors in HSL space.
-func mixHSL(c1, c2 Color, weight float64) Color {
-	hsl1 := ToHSL(c1)
-	hsl2 := ToHSL(c2)
-
-	// Interpolate HSL components
-	h := interpolateHue(hsl1.H, hsl2.H, weight, HueShorter)
-	s := hsl1.S*(1-weight) + hsl2.S*weight
-	l := hsl1.L*(1-weight) + hsl2.L*weight
-	a := hsl1.A*(1-weight) + hsl2.A*weight
-
-	return NewHSL(h, s, l, a)
+// spaceGradientModel interpolates the channels of an RGB Space.
+func spaceGradientModel(space Space) gradientModel {
+	return gradientModel{
+		kinds: [3]componentKind{kindRed, kindGreen, kindBlue}, hue: -1,
+		to: func(c Color) [3]float64 {
+			xyz := ToXYZ(c)
+			ch := space.FromXYZ(xyz.X, xyz.Y, xyz.Z)
+			return [3]float64{ch[0], ch[1], ch[2]}
+		},
+		from: func(ch [3]float64, alpha float64) Color {
+			return NewSpaceColor(space, []float64{ch[0], ch[1], ch[2]}, alpha)
+		},
+	}
 }
 
-// mixLAB mixes colors in CIE LAB space.
-func mixLAB(c1, c2 Color, weight float64) Color {
-	lab1 := ToLAB(c1)
-	lab2 := ToLAB(c2)
-
-	l := lab1.L*(1-weight) + lab2.L*weight
-	a := lab1.A*(1-weight) + lab2.A*weight
-	b := lab1.B*(1-weight) + lab2.B*weight
-	alpha := lab1.Alpha()*(1-weight) + lab2.Alpha()*weight
+// mixInSpace mixes two colors in the specified color space.
+// A component missing (none) in one color takes the other color's value;
+// missing in both, it stays missing in the result.
+// Hues are interpolated with the plain weight; the other channels with the channel weight.
+func mixInSpace(c1, c2 Color, weight float64, space GradientSpace, opts mixOptions) Color {
+	weight = clamp01(weight)
+	model := gradientModelOf(space)
 
-	return NewLAB(l, a, b, alpha)
-}
+	ch1, ch2 := model.to(c1), model.to(c2)
+	a1, a2 := c1.Alpha(), c2.Alpha()
+	missing1, missing2 := missingIn(c1, model.kinds), missingIn(c2, model.kinds)
+	for i := range ch1 {
+		ch1[i], ch2[i] = carryForward(ch1[i], ch2[i], missing1[i], missing2[i])
+	}
+	a1, a2 = carryForward(a1, a2, missing1[3], missing2[3])
 
-// mixOKLAB mixes colors in OKLAB space.
-func mixOKLAB(c1, c2 Color, weight float64) Color {
-	oklab1 := ToOKLAB(c1)
-	oklab2 := ToOKLAB(c2)
+	cw := weight
+	if !math.IsNaN(a1) {
+		cw = opts.channelWeight(a1, a2, weight)
+	}
 
-	l := oklab1.L*(1-weight) + oklab2.L*weight
-	a := oklab1.A*(1-weight) + oklab2.A*weight
-	b := oklab1.B*(1-weight) + oklab2.B*weight
-	alpha := oklab1.Alpha()*(1-weight) + oklab2.Alpha()*weight
+	var mixed [3]float64
+	for i := range mixed {
+		if i == model.hue {
+			mixed[i] = interpolateHue(ch1[i], ch2[i], weight, opts.hue)
+		} else {
+			mixed[i] = ch1[i]*(1-cw) + ch2[i]*cw
+		}
+	}
 
-	return NewOKLAB(l, a, b, alpha)
+	return model.from(mixed, a1*(1-weight)+a2*weight)
 }
 
-// mixLCH mixes colors in CIE LCH space.
-func mixLCH(c1, c2 Color, weight float64) Color {
-	lch1 := ToLCH(c1)
-	lch2 := ToLCH(c2)
-
-	l := lch1.L*(1-weight) + lch2.L*weight
-	c := lch1.C*(1-weight) + lch2.C*weight
-	h := interpolateHue(lch1.H, lch2.H, weight, HueShorter)
-	alpha := lch1.Alpha()*(1-weight) + lch2.Alpha()*weight
-
-	return NewLCH(l, c, h, alpha)
+// carryForward fills a component missing in one color with the other color's value.
+// A component missing in both becomes NaN, so the mix keeps it missing.
+func carryForward(v1, v2 float64, missing1, missing2 bool) (float64, float64) {
+	switch {
+	case missing1 && missing2:
+		return math.NaN(), math.NaN()
+	case missing1:
+		return v2, v2
+	case missing2:
+		return v1, v1
+	}
+	return v1, v2
 }
 
 // GradientStop represents a color stop in a multistop gradient.
@@ -414,4 +571,3 @@ func interpolateHue(h1, h2, weight float64, method HueInterpolation) float64 {
 
 	return normalizeHue(h1 + dh*weight)
 }
-
@@ -4,11 +4,18 @@ package color
 // based on color theory principles. All functions work in the OKLCH color
 // space for perceptually uniform results.
 
+// harmonyColor creates an OKLCH color for a scheme. Rotating the hue or
+// changing the lightness of a vivid color can leave the sRGB gamut, so the
+// chroma is reduced as far as needed, keeping lightness and hue.
+func harmonyColor(l, c, h, alpha float64) Color {
+	return MapToGamut(NewOKLCH(l, c, h, alpha), GamutPreserveLightness)
+}
+
 // Complementary returns the complementary color (opposite on the color wheel).
 // This creates maximum contrast while maintaining harmony.
 func Complementary(c Color) Color {
 	oklch := ToOKLCH(c)
-	return NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_)
+	return harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_)
 }
 
 // Triadic returns a triadic color scheme (3 colors evenly spaced on the color wheel).
@@ -17,8 +24,8 @@ func Triadic(c Color) []Color {
 	oklch := ToOKLCH(c)
 	return []Color{
 		c,
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+120), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+240), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+120), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+240), oklch.A_),
 	}
 }
 
@@ -28,9 +35,9 @@ func Tetradic(c Color) []Color {
 	oklch := ToOKLCH(c)
 	return []Color{
 		c,
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+90), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+270), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+90), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+270), oklch.A_),
 	}
 }
 
@@ -65,7 +72,7 @@ func AnalogousN(c Color, n int, angle float64) []Color {
 	// Distribute colors evenly from -angle to +angle
 	for i := 0; i < n; i++ {
 		offset := -angle + (float64(i) / float64(n-1) * 2 * angle)
-		colors[i] = NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+offset), oklch.A_)
+		colors[i] = harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+offset), oklch.A_)
 	}
 
 	return colors
@@ -86,8 +93,8 @@ func SplitComplementaryN(c Color, angle float64) []Color {
 
 	return []Color{
 		c,
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(complement-angle), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(complement+angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(complement-angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(complement+angle), oklch.A_),
 	}
 }
 
@@ -116,7 +123,7 @@ func Monochromatic(c Color, n int) []Color {
 		if l < 0.3 || l > 0.85 {
 			adjustedC = oklch.C * 0.7
 		}
-		colors[i] = NewOKLCH(l, adjustedC, oklch.H, oklch.A_)
+		colors[i] = harmonyColor(l, adjustedC, oklch.H, oklch.A_)
 	}
 
 	return colors
@@ -149,7 +156,7 @@ func MonochromaticCentered(c Color, n int) []Color {
 			adjustedC = oklch.C * 0.7
 		}
 
-		colors[i] = NewOKLCH(l, adjustedC, oklch.H, oklch.A_)
+		colors[i] = harmonyColor(l, adjustedC, oklch.H, oklch.A_)
 	}
 
 	return colors
@@ -175,7 +182,7 @@ func Shades(c Color, n int) []Color {
 		l := oklch.L * (1 - t) + minL * t
 		// Reduce chroma as we get darker
 		adjustedC := oklch.C * (1 - t*0.3)
-		colors[i] = NewOKLCH(l, adjustedC, oklch.H, oklch.A_)
+		colors[i] = harmonyColor(l, adjustedC, oklch.H, oklch.A_)
 	}
 
 	return colors
@@ -201,7 +208,7 @@ func Tints(c Color, n int) []Color {
 		l := oklch.L * (1 - t) + maxL * t
 		// Reduce chroma as we get lighter
 		adjustedC := oklch.C * (1 - t*0.5)
-		colors[i] = NewOKLCH(l, adjustedC, oklch.H, oklch.A_)
+		colors[i] = harmonyColor(l, adjustedC, oklch.H, oklch.A_)
 	}
 
 	return colors
@@ -224,7 +231,7 @@ func Tones(c Color, n int) []Color {
 		t := float64(i) / float64(n-1)
 		// Reduce chroma to 0 (gray)
 		adjustedC := oklch.C * (1 - t)
-		colors[i] = NewOKLCH(oklch.L, adjustedC, oklch.H, oklch.A_)
+		colors[i] = harmonyColor(oklch.L, adjustedC, oklch.H, oklch.A_)
 	}
 
 	return colors
@@ -238,9 +245,9 @@ func Rectangle(c Color, angle float64) []Color {
 
 	return []Color{
 		c,
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+angle), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+180+angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+180), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+180+angle), oklch.A_),
 	}
 }
 
@@ -253,10 +260,10 @@ func DoubleSplitComplementary(c Color) []Color {
 
 	return []Color{
 		c,
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H-angle), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(oklch.H+angle), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(complement), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(complement-angle), oklch.A_),
-		NewOKLCH(oklch.L, oklch.C, normalizeHue(complement+angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H-angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(oklch.H+angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(complement), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(complement-angle), oklch.A_),
+		harmonyColor(oklch.L, oklch.C, normalizeHue(complement+angle), oklch.A_),
 	}
 }
@@ -0,0 +1,218 @@
+package color
+
+import "math"
+
+// HCT represents a color in the HCT color space used by Material Design 3:
+// hue and chroma from CAM16, and tone, which is CIE L*.
+// H is hue [0, 360), C is chroma [0, ~150], T is tone [0, 100].
+//
+// Tone predicts contrast: any two colors whose tones differ by 50 or more
+// have a WCAG contrast ratio of at least 4.5, whatever their hue and chroma.
+type HCT struct {
+	H, C, T, A_ float64 // A_ is alpha to avoid conflict with method
+
+	// NewHCT solves for the sRGB color once and keeps its XYZ, valid while
+	// H, C and T are still the solved values.
+	xyz, solvedFor [3]float64
+	solved         bool
+}
+
+// NewHCT creates an HCT color from a hue, chroma and tone. Not every
+// combination exists in sRGB; the chroma is reduced as far as needed to fit,
+// keeping hue and tone, and the result holds the HCT of that sRGB color.
+//
+// Example:
+//   c := color.NewHCT(282, 87, 50, 1)  // a vivid blue at tone 50
+func NewHCT(h, c, t, alpha float64) *HCT {
+	x, y, z := solveHCT(h, c, t)
+	hct := xyzToHCT(x, y, z)
+	hct.A_ = clamp01(alpha)
+	hct.xyz, hct.solvedFor, hct.solved = [3]float64{x, y, z}, [3]float64{hct.H, hct.C, hct.T}, true
+	return hct
+}
+
+// RGBA converts HCT to RGBA.
+func (c *HCT) RGBA() (r, g, b, a float64) {
+	return c.toXYZ().RGBA()
+}
+
+// linearRGBA implements linearColor.
+func (c *HCT) linearRGBA() (r, g, b, a float64) {
+	return c.toXYZ().linearRGBA()
+}
+
+// Alpha implements Color.
+func (c *HCT) Alpha() float64 {
+	return c.A_
+}
+
+// WithAlpha implements Color.
+func (c *HCT) WithAlpha(alpha float64) Color {
+	hct := *c
+	hct.A_ = clamp01(alpha)
+	return &hct
+}
+
+// toXYZ converts HCT to XYZ inside the sRGB gamut, solving for it unless
+// NewHCT already has.
+func (c *HCT) toXYZ() *XYZ {
+	if c.solved && c.solvedFor == [3]float64{c.H, c.C, c.T} {
+		return &XYZ{X: c.xyz[0], Y: c.xyz[1], Z: c.xyz[2], A: c.A_}
+	}
+	x, y, z := solveHCT(c.H, c.C, c.T)
+	return &XYZ{X: x, Y: y, Z: z, A: c.A_}
+}
+
+// ToHCT converts a Color to HCT.
+func ToHCT(c Color) *HCT {
+	xyz := ToXYZ(c)
+	hct := xyzToHCT(xyz.X, xyz.Y, xyz.Z)
+	hct.A_ = xyz.A
+	return hct
+}
+
+// hctConditions are the CAM16 viewing conditions of HCT, the defaults of
+// Material Design: a D65 display in an average surround with a mid-gray
+// (L* = 50) background, adapted to 200 lux.
+var hctConditions = ViewingConditions{
+	White:               whiteD65,
+	AdaptingLuminance:   200 / math.Pi * yFromLstar(50),
+	BackgroundLuminance: yFromLstar(50) * 100,
+	Surround:            SurroundAverage,
+}
+
+// hctParams are the CAM16 values for hctConditions.
+var hctParams = hctConditions.params()
+
+// xyzToHCT converts XYZ to HCT, ignoring alpha.
+func xyzToHCT(x, y, z float64) *HCT {
+	_, c, h := hctParams.fromXYZ(x, y, z)
+	return &HCT{H: h, C: c, T: lstarFromY(y)}
+}
+
+// hctToXYZ converts HCT to XYZ without regard to any gamut: it finds the
+// CAM16 lightness J whose color, at the given hue and chroma, has the given tone.
+func hctToXYZ(h, c, t float64) (x, y, z float64) {
+	if t <= 0 {
+		return 0, 0, 0
+	}
+	targetY := yFromLstar(t)
+
+	// Y increases with J at a fixed hue and chroma; bisect for it
+	lo, hi := 0.0, 100.0
+	for {
+		_, hiY, _ := hctParams.toXYZ(hi, c, h)
+		if hiY >= targetY || hi > 1e4 {
+			break
+		}
+		lo, hi = hi, hi*2
+	}
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if _, midY, _ := hctParams.toXYZ(mid, c, h); midY < targetY {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hctParams.toXYZ((lo+hi)/2, c, h)
+}
+
+// solveHCT finds the sRGB color with the given hue and tone and the chroma
+// closest to c, returned as XYZ. If c does not fit in sRGB at that hue and
+// tone, the largest chroma that does is used.
+func solveHCT(h, c, t float64) (x, y, z float64) {
+	if t <= 0 {
+		return 0, 0, 0
+	}
+	if t >= 100 {
+		return linearSRGBToXYZ(1, 1, 1)
+	}
+	c = math.Max(0, c)
+
+	inGamut := func(chroma float64) (bool, [3]float64) {
+		x, y, z := hctToXYZ(h, chroma, t)
+		r, g, b := xyzToLinearSRGB(x, y, z)
+		ok := inUnitRange(r) && inUnitRange(g) && inUnitRange(b)
+		return ok, [3]float64{x, y, z}
+	}
+
+	if ok, xyz := inGamut(c); ok {
+		return xyz[0], xyz[1], xyz[2]
+	}
+
+	// Bisect for the largest chroma in gamut; a gray of the tone always is
+	lo, hi := 0.0, c
+	_, best := inGamut(0)
+	for hi-lo > 1e-4 {
+		mid := (lo + hi) / 2
+		if ok, xyz := inGamut(mid); ok {
+			lo, best = mid, xyz
+		} else {
+			hi = mid
+		}
+	}
+	return best[0], best[1], best[2]
+}
+
+// lstarFromY converts relative luminance Y (white = 1) to CIE L*.
+func lstarFromY(y float64) float64 {
+	const e, k = 216.0 / 24389, 24389.0 / 27
+	if y <= e {
+		return k * y
+	}
+	return 116*math.Cbrt(y) - 16
+}
+
+// yFromLstar converts CIE L* to relative luminance Y (white = 1).
+func yFromLstar(l float64) float64 {
+	const k = 24389.0 / 27
+	if l <= 8 {
+		return l / k
+	}
+	f := (l + 16) / 116
+	return f * f * f
+}
+
+// TonalPalette is a Material Design tonal palette: colors of one hue and
+// chroma at every tone from 0 (black) to 100 (white).
+type TonalPalette struct {
+	Hue, Chroma float64
+}
+
+// MaterialTones are the tones Material Design uses in its palettes.
+var MaterialTones = []float64{0, 10, 20, 25, 30, 35, 40, 50, 60, 70, 80, 90, 95, 98, 99, 100}
+
+// NewTonalPalette creates a tonal palette with the hue and chroma of a seed color.
+//
+// Example:
+//   p := color.NewTonalPalette(brand)
+//   primary := p.Tone(40)
+//   onPrimary := p.Tone(100)
+//   container := p.Tone(90)
+func NewTonalPalette(seed Color) *TonalPalette {
+	hct := ToHCT(seed)
+	return &TonalPalette{Hue: hct.H, Chroma: hct.C}
+}
+
+// Tone returns the palette's color at a tone from 0 to 100, in sRGB.
+// Where the palette's chroma does not fit at that tone, the most
+// chromatic color that does is returned.
+func (p *TonalPalette) Tone(t float64) *RGBA {
+	x, y, z := solveHCT(p.Hue, p.Chroma, t)
+	r, g, b := xyzToLinearSRGB(x, y, z)
+	return NewRGBA(gammaCorrection(r), gammaCorrection(g), gammaCorrection(b), 1)
+}
+
+// Tones returns the palette's colors at the given tones,
+// or at MaterialTones if none are given.
+func (p *TonalPalette) Tones(tones ...float64) []Color {
+	if len(tones) == 0 {
+		tones = MaterialTones
+	}
+	colors := make([]Color, len(tones))
+	for i, t := range tones {
+		colors[i] = p.Tone(t)
+	}
+	return colors
+}
@@ -0,0 +1,128 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHCTReference(t *testing.T) {
+	// Reference values from Material Color Utilities, which uses a slightly
+	// different sRGB matrix, hence the tolerance
+	tests := []struct {
+		hex     string
+		h, c, t float64
+	}{
+		{"#ff0000", 27.408, 113.357, 53.233},
+		{"#00ff00", 142.139, 108.410, 87.737},
+		{"#0000ff", 282.788, 87.230, 32.302},
+		{"#ffffff", 209.492, 2.869, 100},
+	}
+	for _, tt := range tests {
+		c, _ := HexToRGB(tt.hex)
+		hct := ToHCT(c)
+		if !floatNear(hct.H, tt.h, 0.05) || !floatNear(hct.C, tt.c, 0.05) || !floatNear(hct.T, tt.t, 0.05) {
+			t.Errorf("ToHCT(%s) = %.3f %.3f %.3f, want %.3f %.3f %.3f", tt.hex, hct.H, hct.C, hct.T, tt.h, tt.c, tt.t)
+		}
+	}
+}
+
+func TestTonalPaletteReference(t *testing.T) {
+	// Material Color Utilities: TonalPalette.fromInt(0xff0000ff)
+	blue, _ := HexToRGB("#0000ff")
+	p := NewTonalPalette(blue)
+	want := map[float64]string{
+		100: "#ffffff",
+		95:  "#f1efff",
+		90:  "#e0e0ff",
+		80:  "#bec2ff",
+		70:  "#9da3ff",
+		60:  "#7c84ff",
+		50:  "#5a64ff",
+		40:  "#343dff",
+		30:  "#0000ef",
+		20:  "#0001ac",
+		10:  "#00006e",
+		0:   "#000000",
+	}
+	for tone, hex := range want {
+		got := p.Tone(tone)
+		w, _ := HexToRGB(hex)
+		if math.Abs(got.R-w.R) > 1.5/255 || math.Abs(got.G-w.G) > 1.5/255 || math.Abs(got.B-w.B) > 1.5/255 {
+			t.Errorf("Tone(%g) = %s, want %s", tone, Format(got, FormatOptions{Hex: true}), hex)
+		}
+	}
+}
+
+func TestNewHCT(t *testing.T) {
+	// In gamut: hue, chroma and tone are kept
+	c := NewHCT(120, 30, 60, 1)
+	if !floatNear(c.H, 120, 1e-6) || !floatNear(c.C, 30, 1e-4) || !floatNear(c.T, 60, 1e-6) {
+		t.Errorf("NewHCT(120, 30, 60) = %+v", c)
+	}
+	if !InGamut(c) {
+		t.Error("NewHCT result is outside sRGB")
+	}
+
+	// Out of gamut: chroma is reduced, hue and tone are kept
+	c = NewHCT(282, 150, 80, 0.5)
+	if c.C >= 150 || !floatNear(c.H, 282, 0.1) || !floatNear(c.T, 80, 0.01) {
+		t.Errorf("NewHCT(282, 150, 80) = %+v", c)
+	}
+	if !InGamut(c) || c.Alpha() != 0.5 {
+		t.Errorf("NewHCT result: in gamut %v, alpha %g", InGamut(c), c.Alpha())
+	}
+
+	// The tone is L*
+	if got := ToLAB(NewHCT(40, 20, 70, 1)).L; !floatNear(got, 70, 0.05) {
+		t.Errorf("L* = %g, want 70", got)
+	}
+}
+
+func TestHCTSpace(t *testing.T) {
+	c := NewSpaceColor(HCTSpace, []float64{200, 40, 55}, 1)
+	back := c.ConvertTo(SRGBSpace).ConvertTo(HCTSpace).Channels()
+	for i, v := range []float64{200, 40, 55} {
+		if !floatNear(back[i], v, 1e-4) {
+			t.Errorf("round trip = %v", back)
+			break
+		}
+	}
+	if space, ok := GetSpace("hct"); !ok || space != HCTSpace {
+		t.Error("hct is not registered")
+	}
+}
+
+func TestTonalPaletteTones(t *testing.T) {
+	p := &TonalPalette{Hue: 30, Chroma: 40}
+	tones := p.Tones()
+	if len(tones) != len(MaterialTones) {
+		t.Fatalf("Tones() returned %d colors", len(tones))
+	}
+	for i := 1; i < len(tones); i++ {
+		if ToHCT(tones[i]).T <= ToHCT(tones[i-1]).T {
+			t.Errorf("tone %g is not lighter than tone %g", MaterialTones[i], MaterialTones[i-1])
+		}
+	}
+	if got := p.Tones(10, 90); len(got) != 2 {
+		t.Errorf("Tones(10, 90) returned %d colors", len(got))
+	}
+}
+
+func TestHCTCachedXYZ(t *testing.T) {
+	c := NewHCT(282, 87, 50, 1)
+	r, g, b, _ := c.RGBA()
+
+	// The cached solution matches solving again from the fields
+	fresh := &HCT{H: c.H, C: c.C, T: c.T, A_: 1}
+	fr, fg, fb, _ := fresh.RGBA()
+	if !floatNear(r, fr, 1e-6) || !floatNear(g, fg, 1e-6) || !floatNear(b, fb, 1e-6) {
+		t.Errorf("Cached RGBA = (%f, %f, %f), solved = (%f, %f, %f)", r, g, b, fr, fg, fb)
+	}
+
+	// Changing a field solves for the new values
+	c.T = 80
+	if tone := ToHCT(c).T; !floatNear(tone, 80, 0.01) {
+		t.Errorf("Tone after setting T = 80 is %f", tone)
+	}
+}
+
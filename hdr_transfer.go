@@ -0,0 +1,94 @@
+package color
+
+import "math"
+
+// SMPTE ST 2084 (PQ) constants
+const (
+	pqM1 = 2610.0 / 16384
+	pqM2 = 2523.0 / 4096 * 128
+	pqC1 = 3424.0 / 4096
+	pqC2 = 2413.0 / 4096 * 32
+	pqC3 = 2392.0 / 4096 * 32
+)
+
+// pqEncode is the PQ inverse EOTF: luminance as a fraction of 10,000 cd/m²
+// to signal. Negative values are mirrored.
+func pqEncode(v float64) float64 {
+	vm := math.Pow(math.Abs(v), pqM1)
+	return math.Copysign(math.Pow((pqC1+pqC2*vm)/(1+pqC3*vm), pqM2), v)
+}
+
+// pqDecode is the PQ EOTF: signal to luminance as a fraction of 10,000 cd/m².
+func pqDecode(e float64) float64 {
+	ep := math.Pow(math.Abs(e), 1/pqM2)
+	v := math.Pow(math.Max(0, ep-pqC1)/(pqC2-pqC3*ep), 1/pqM1)
+	return math.Copysign(v, e)
+}
+
+// ARIB STD-B67 (HLG) constants
+const (
+	hlgA = 0.17883277
+	hlgB = 1 - 4*hlgA
+	hlgC = 0.55991072952956202 // 0.5 - a*ln(4a)
+)
+
+// hlgEncode is the HLG OETF: normalized scene light [0, 1] to signal.
+// Negative values are mirrored.
+func hlgEncode(e float64) float64 {
+	ae := math.Abs(e)
+	var v float64
+	if ae <= 1.0/12 {
+		v = math.Sqrt(3 * ae)
+	} else {
+		v = hlgA*math.Log(12*ae-hlgB) + hlgC
+	}
+	return math.Copysign(v, e)
+}
+
+// hlgDecode is the inverse HLG OETF: signal to normalized scene light.
+func hlgDecode(v float64) float64 {
+	av := math.Abs(v)
+	var e float64
+	if av <= 0.5 {
+		e = av * av / 3
+	} else {
+		e = (math.Exp((av-hlgC)/hlgA) + hlgB) / 12
+	}
+	return math.Copysign(e, v)
+}
+
+// hlgReferenceWhite is the scene light of HDR reference white, HLG signal
+// 0.75 (ITU-R BT.2408), where relative Y = 1 is placed in HLG.
+var hlgReferenceWhite = hlgDecode(0.75)
+
+// PQEOTF is the SMPTE ST 2084 (PQ) EOTF: it converts a PQ signal [0, 1]
+// to display luminance in cd/m² [0, 10000].
+func PQEOTF(signal float64) float64 {
+	return pqDecode(signal) * 10000
+}
+
+// PQInverseEOTF converts display luminance in cd/m² [0, 10000] to a PQ signal [0, 1].
+//
+// Example:
+//   color.PQInverseEOTF(1000)  // 0.7518, the signal of a 1000-nit highlight
+func PQInverseEOTF(luminance float64) float64 {
+	return pqEncode(luminance / 10000)
+}
+
+// HLGOETF is the ARIB STD-B67 / ITU-R BT.2100 HLG OETF: it converts
+// normalized scene light [0, 1] to an HLG signal [0, 1].
+func HLGOETF(sceneLight float64) float64 {
+	return hlgEncode(sceneLight)
+}
+
+// HLGInverseOETF converts an HLG signal [0, 1] to normalized scene light [0, 1].
+func HLGInverseOETF(signal float64) float64 {
+	return hlgDecode(signal)
+}
+
+// HLGSystemGamma returns the HLG system gamma for a display of the given
+// peak luminance in cd/m² (ITU-R BT.2100): 1.2 at 1000 cd/m², and
+// 1.2 + 0.42·log10(peak/1000) otherwise.
+func HLGSystemGamma(peakLuminance float64) float64 {
+	return 1.2 + 0.42*math.Log10(peakLuminance/1000)
+}
@@ -4,88 +4,54 @@ import "fmt"
 
 // HexToRGB converts a hex color string (with or without #) to RGB.
 // Supports formats: #RGB, #RRGGBB, #RGBA, #RRGGBBAA
+//
+// Errors are *HexParseError values wrapping a *ParseError, whose Offset points
+// at the first invalid digit; errors.Is(err, ErrBadHex) reports them.
 func HexToRGB(hex string) (*RGBA, error) {
+	c, err := hexToRGB(hex)
+	if err != nil {
+		return nil, &HexParseError{hex: hex, err: err}
+	}
+	return c, nil
+}
+
+// hexToRGB implements HexToRGB, returning the *ParseError that ParseColor reports.
+func hexToRGB(hex string) (*RGBA, *ParseError) {
+	input := hex
+
 	// Remove # if present
+	prefix := 0
 	if len(hex) > 0 && hex[0] == '#' {
 		hex = hex[1:]
+		prefix = 1
 	}
 
-	var r, g, b, a float64
-	var err error
+	for i := 0; i < len(hex); i++ {
+		if _, err := hexDigitToInt(hex[i]); err != nil {
+			e := newParseError(ErrBadHex, input, fmt.Sprintf("invalid hex digit %q", hex[i]))
+			e.tokenPos = prefix + i
+			e.Offset = e.tokenPos
+			return nil, e
+		}
+	}
+
+	// The digits are valid, so parseHexByte cannot fail
+	byteAt := func(high, low byte) float64 {
+		v, _ := parseHexByte(high, low)
+		return v
+	}
 
 	switch len(hex) {
 	case 3: // #RGB
-		r, err = parseHexByte(hex[0], hex[0])
-		if err != nil {
-			return nil, err
-		}
-		g, err = parseHexByte(hex[1], hex[1])
-		if err != nil {
-			return nil, err
-		}
-		b, err = parseHexByte(hex[2], hex[2])
-		if err != nil {
-			return nil, err
-		}
-		a = 1.0
+		return NewRGBA(byteAt(hex[0], hex[0])/255.0, byteAt(hex[1], hex[1])/255.0, byteAt(hex[2], hex[2])/255.0, 1.0), nil
 	case 4: // #RGBA
-		r, err = parseHexByte(hex[0], hex[0])
-		if err != nil {
-			return nil, err
-		}
-		g, err = parseHexByte(hex[1], hex[1])
-		if err != nil {
-			return nil, err
-		}
-		b, err = parseHexByte(hex[2], hex[2])
-		if err != nil {
-			return nil, err
-		}
-		a, err = parseHexByte(hex[3], hex[3])
-		if err != nil {
-			return nil, err
-		}
+		return NewRGBA(byteAt(hex[0], hex[0])/255.0, byteAt(hex[1], hex[1])/255.0, byteAt(hex[2], hex[2])/255.0, byteAt(hex[3], hex[3])/255.0), nil
 	case 6: // #RRGGBB
-		r, err = parseHexByte(hex[0], hex[1])
-		if err != nil {
-			return nil, err
-		}
-		g, err = parseHexByte(hex[2], hex[3])
-		if err != nil {
-			return nil, err
-		}
-		b, err = parseHexByte(hex[4], hex[5])
-		if err != nil {
-			return nil, err
-		}
-		a = 1.0
+		return NewRGBA(byteAt(hex[0], hex[1])/255.0, byteAt(hex[2], hex[3])/255.0, byteAt(hex[4], hex[5])/255.0, 1.0), nil
 	case 8: // #RRGGBBAA
-		r, err = parseHexByte(hex[0], hex[1])
-		if err != nil {
-			return nil, err
-		}
-		g, err = parseHexByte(hex[2], hex[3])
-		if err != nil {
-			return nil, err
-		}
-		b, err = parseHexByte(hex[4], hex[5])
-		if err != nil {
-			return nil, err
-		}
-		a, err = parseHexByte(hex[6], hex[7])
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, &HexParseError{hex: hex}
-	}
-
-	// For cases 3 and 6, alpha is already 1.0 (not divided by 255)
-	// For cases 4 and 8, alpha needs to be divided by 255
-	if len(hex) == 3 || len(hex) == 6 {
-		return NewRGBA(r/255.0, g/255.0, b/255.0, 1.0), nil
+		return NewRGBA(byteAt(hex[0], hex[1])/255.0, byteAt(hex[2], hex[3])/255.0, byteAt(hex[4], hex[5])/255.0, byteAt(hex[6], hex[7])/255.0), nil
 	}
-	return NewRGBA(r/255.0, g/255.0, b/255.0, a/255.0), nil
+	return nil, newParseError(ErrBadHex, input, "hex color must have 3, 4, 6 or 8 digits")
 }
 
 // parseHexByte parses a single hex byte (one or two hex digits).
@@ -116,14 +82,25 @@ func hexDigitToInt(b byte) (int, error) {
 }
 
 // HexParseError represents an error parsing a hex color string.
+// HexToRGB returns it wrapping a *ParseError with the position of the error;
+// ParseColor returns the *ParseError itself.
 type HexParseError struct {
 	hex string
+	err *ParseError
 }
 
 func (e *HexParseError) Error() string {
 	return "invalid hex color: " + e.hex
 }
 
+// Unwrap returns the *ParseError, or ErrBadHex if there is none.
+func (e *HexParseError) Unwrap() error {
+	if e.err == nil {
+		return ErrBadHex
+	}
+	return e.err
+}
+
 // RGBToHex converts an RGB color to a hex string.
 // Returns format #RRGGBB or #RRGGBBAA if alpha < 1.0.
 func RGBToHex(c Color) string {
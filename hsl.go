@@ -20,13 +20,14 @@ func NewHSL(h, s, l, a float64) *HSL {
 
 // RGBA converts HSL to RGBA.
 func (c *HSL) RGBA() (r, g, b, a float64) {
-	h := c.H / 360.0
-	s := c.S
-	l := c.L
+	h := resolveNone(c.H) / 360.0
+	s := resolveNone(c.S)
+	l := resolveNone(c.L)
+	a = resolveNone(c.A)
 
 	if s == 0 {
 		// Achromatic (gray)
-		return l, l, l, c.A
+		return l, l, l, a
 	}
 
 	var q, p float64
@@ -41,12 +42,12 @@ func (c *HSL) RGBA() (r, g, b, a float64) {
 	g = hueToRGB(p, q, h)
 	b = hueToRGB(p, q, h-1.0/3.0)
 
-	return clamp01(r), clamp01(g), clamp01(b), c.A
+	return clamp01(r), clamp01(g), clamp01(b), a
 }
 
 // Alpha implements Color.
 func (c *HSL) Alpha() float64 {
-	return c.A
+	return resolveNone(c.A)
 }
 
 // WithAlpha implements Color.
@@ -54,6 +55,11 @@ func (c *HSL) WithAlpha(alpha float64) Color {
 	return &HSL{H: c.H, S: c.S, L: c.L, A: clamp01(alpha)}
 }
 
+// String returns a CSS hsl() representation.
+func (c *HSL) String() string {
+	return CSSString(c)
+}
+
 // hueToRGB is a helper function for HSL to RGB conversion.
 func hueToRGB(p, q, t float64) float64 {
 	if t < 0 {
@@ -20,9 +20,9 @@ func NewHSV(h, s, v, a float64) *HSV {
 
 // RGBA converts HSV to RGBA.
 func (c *HSV) RGBA() (r, g, b, a float64) {
-	h := c.H / 60.0
-	s := c.S
-	v := c.V
+	h := resolveNone(c.H) / 60.0
+	s := resolveNone(c.S)
+	v := resolveNone(c.V)
 
 	c_ := v * s
 	x := c_ * (1 - math.Abs(math.Mod(h, 2)-1))
@@ -48,12 +48,12 @@ func (c *HSV) RGBA() (r, g, b, a float64) {
 	g = g1 + m
 	b = b1 + m
 
-	return clamp01(r), clamp01(g), clamp01(b), c.A
+	return clamp01(r), clamp01(g), clamp01(b), resolveNone(c.A)
 }
 
 // Alpha implements Color.
 func (c *HSV) Alpha() float64 {
-	return c.A
+	return resolveNone(c.A)
 }
 
 // WithAlpha implements Color.
@@ -35,15 +35,18 @@ func NewHWB(h, w, b, a float64) *HWB {
 
 // RGBA converts HWB to RGBA.
 func (c *HWB) RGBA() (r, g, b, a float64) {
+	w, bl := resolveNone(c.W), resolveNone(c.B)
+	a = resolveNone(c.A)
+
 	// If whiteness + blackness = 1, return gray
-	sum := c.W + c.B
+	sum := w + bl
 	if sum >= 1 {
-		gray := c.W / sum
-		return gray, gray, gray, c.A
+		gray := w / sum
+		return gray, gray, gray, a
 	}
 
 	// Convert hue to base RGB (pure hue)
-	h := c.H / 60.0
+	h := resolveNone(c.H) / 60.0
 	x := 1 - math.Abs(math.Mod(h, 2)-1)
 
 	var r1, g1, b1 float64
@@ -66,17 +69,16 @@ func (c *HWB) RGBA() (r, g, b, a float64) {
 
 	// Apply whiteness and blackness
 	// Formula: RGB = (RGB_pure * (1 - W - B)) + W
-	r = r1*(1-c.W-c.B) + c.W
-	g = g1*(1-c.W-c.B) + c.W
-	b = b1*(1-c.W-c.B) + c.W
-	a = c.A
+	r = r1*(1-w-bl) + w
+	g = g1*(1-w-bl) + w
+	b = b1*(1-w-bl) + w
 
 	return clamp01(r), clamp01(g), clamp01(b), clamp01(a)
 }
 
 // Alpha implements Color.
 func (c *HWB) Alpha() float64 {
-	return c.A
+	return resolveNone(c.A)
 }
 
 // WithAlpha implements Color.
@@ -84,6 +86,11 @@ func (c *HWB) WithAlpha(alpha float64) Color {
 	return &HWB{H: c.H, W: c.W, B: c.B, A: clamp01(alpha)}
 }
 
+// String returns a CSS hwb() representation.
+func (c *HWB) String() string {
+	return CSSString(c)
+}
+
 // ToHWB converts a Color to HWB.
 func ToHWB(c Color) *HWB {
 	r, g, b, a := c.RGBA()
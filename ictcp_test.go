@@ -0,0 +1,90 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHDRTransferFunctions(t *testing.T) {
+	// ST 2084 reference points: 100, 1000 and 10,000 cd/m²
+	pq := []struct{ nits, signal float64 }{{100, 0.50808}, {1000, 0.75183}, {10000, 1}}
+	for _, tt := range pq {
+		if got := pqEncode(tt.nits / 10000); !floatNear(got, tt.signal, 1e-5) {
+			t.Errorf("pqEncode(%g nits) = %g, want %g", tt.nits, got, tt.signal)
+		}
+		if got := pqDecode(tt.signal) * 10000; !floatNear(got, tt.nits, tt.nits*1e-4) {
+			t.Errorf("pqDecode(%g) = %g nits, want %g", tt.signal, got, tt.nits)
+		}
+	}
+
+	// HLG: 1/12 encodes to 0.5 and 1 to 1
+	if got := hlgEncode(1.0 / 12); !floatNear(got, 0.5, 1e-12) {
+		t.Errorf("hlgEncode(1/12) = %g", got)
+	}
+	if got := hlgEncode(1); !floatNear(got, 1, 1e-7) {
+		t.Errorf("hlgEncode(1) = %g", got)
+	}
+	for _, v := range []float64{0, 0.01, 0.2, 0.5, 0.75, 1} {
+		if got := hlgEncode(hlgDecode(v)); !floatNear(got, v, 1e-12) {
+			t.Errorf("hlgEncode(hlgDecode(%g)) = %g", v, got)
+		}
+		// PQ maps 0 to 7.3e-7 rather than 0
+		if got := pqEncode(pqDecode(v)); !floatNear(got, v, 1e-6) {
+			t.Errorf("pqEncode(pqDecode(%g)) = %g", v, got)
+		}
+	}
+}
+
+func TestICtCp(t *testing.T) {
+	white := ToXYZ(RGB(1, 1, 1))
+
+	// SDR white at 203 cd/m² is PQ 0.58; in HLG it is reference white, 0.75
+	itp := ICtCpSpace.FromXYZ(white.X, white.Y, white.Z)
+	if !floatNear(itp[0], 0.5807, 1e-4) || math.Abs(itp[1]) > 1e-4 || math.Abs(itp[2]) > 1e-4 {
+		t.Errorf("ICtCp(white) = %v", itp)
+	}
+	itp = ICtCpHLGSpace.FromXYZ(white.X, white.Y, white.Z)
+	if !floatNear(itp[0], 0.75, 1e-4) || math.Abs(itp[1]) > 1e-4 || math.Abs(itp[2]) > 1e-4 {
+		t.Errorf("ICtCp-HLG(white) = %v", itp)
+	}
+
+	colors := []Color{
+		RGB(1, 0, 0), RGB(0.2, 0.4, 0.6), RGB(0.01, 0.02, 0.03),
+		NewXYZ(4, 4.2, 4.5, 1), // HDR highlight, about 850 cd/m²
+		NewSpaceColor(Rec2020Space, []float64{0, 1, 0}, 1),
+	}
+	for _, space := range []Space{ICtCpSpace, ICtCpHLGSpace} {
+		for _, c := range colors {
+			xyz := ToXYZ(c)
+			x, y, z := space.ToXYZ(space.FromXYZ(xyz.X, xyz.Y, xyz.Z))
+			if !floatNear(x, xyz.X, 1e-9) || !floatNear(y, xyz.Y, 1e-9) || !floatNear(z, xyz.Z, 1e-9) {
+				t.Errorf("%s round trip of %v = %g %g %g", space.Name(), c, x, y, z)
+			}
+		}
+	}
+
+	for name, want := range map[string]Space{"ictcp": ICtCpSpace, "ictcp-pq": ICtCpSpace, "ictcp-hlg": ICtCpHLGSpace} {
+		if space, ok := GetSpace(name); !ok || space != want {
+			t.Errorf("%s is not registered", name)
+		}
+	}
+}
+
+func TestDeltaEITP(t *testing.T) {
+	a := RGB(0.5, 0.2, 0.2)
+	if d := DeltaEITP(a, a); d != 0 {
+		t.Errorf("DeltaEITP(a, a) = %g", d)
+	}
+	small, large := DeltaEITP(a, RGB(0.51, 0.2, 0.2)), DeltaEITP(a, RGB(0.2, 0.5, 0.2))
+	if small <= 0 || small >= large {
+		t.Errorf("DeltaEITP small = %g, large = %g", small, large)
+	}
+
+	// A pure luminance step: 720 times the difference in I
+	w1, w2 := NewXYZ(0.95047, 1, 1.08883, 1), NewXYZ(0.95047*2, 2, 1.08883*2, 1)
+	i1 := ICtCpSpace.FromXYZ(w1.X, w1.Y, w1.Z)[0]
+	i2 := ICtCpSpace.FromXYZ(w2.X, w2.Y, w2.Z)[0]
+	if d := DeltaEITP(w1, w2); !floatNear(d, 720*(i2-i1), 1e-6) {
+		t.Errorf("DeltaEITP(white, 2×white) = %g, want %g", d, 720*(i2-i1))
+	}
+}
@@ -0,0 +1,175 @@
+package color
+
+import (
+	"sort"
+	"strings"
+)
+
+// WhitePoint is a reference white, given by its XYZ tristimulus values
+// normalized to Y = 1.
+type WhitePoint struct {
+	Name    string
+	X, Y, Z float64
+}
+
+// NewWhitePoint creates a white point from its CIE xy chromaticity.
+// It panics if y <= 0.
+//
+// Example:
+//   // A display measured at x 0.3134, y 0.3291
+//   native := color.NewWhitePoint("native", 0.3134, 0.3291)
+func NewWhitePoint(name string, x, y float64) WhitePoint {
+	if y <= 0 {
+		panic("color: white point must have y > 0")
+	}
+	return WhitePoint{Name: name, X: x / y, Y: 1, Z: (1 - x - y) / y}
+}
+
+// Chromaticity returns the white point's CIE xy chromaticity.
+func (w WhitePoint) Chromaticity() (x, y float64) {
+	sum := w.X + w.Y + w.Z
+	if sum == 0 {
+		return 0, 0
+	}
+	return w.X / sum, w.Y / sum
+}
+
+// xyz returns the white point's XYZ, with Y = 1.
+// The zero WhitePoint is D65.
+func (w WhitePoint) xyz() [3]float64 {
+	if w.Y == 0 {
+		return whiteD65
+	}
+	return [3]float64{w.X / w.Y, 1, w.Z / w.Y}
+}
+
+// Common white points for the CIE 1931 2° observer
+var (
+	// WhiteD65 is the standard white point for most RGB spaces (6500K)
+	WhiteD65 = WhitePoint{Name: "D65", X: whiteD65[0], Y: 1, Z: whiteD65[2]}
+	// WhiteD50 is used by ProPhoto RGB and ICC LAB (5000K)
+	WhiteD50 = WhitePoint{Name: "D50", X: whiteD50[0], Y: 1, Z: whiteD50[2]}
+	// WhiteACES is the ACES white point, close to D60 (6000K)
+	WhiteACES = WhitePoint{Name: "ACES", X: whiteACES[0], Y: 1, Z: whiteACES[2]}
+
+	// WhiteA is incandescent (tungsten) light (2856K)
+	WhiteA = illuminants2["A"]
+	// WhiteC is average daylight, superseded by D65
+	WhiteC = illuminants2["C"]
+	// WhiteD55 is mid-morning daylight, used in photography (5500K)
+	WhiteD55 = illuminants2["D55"]
+	// WhiteD60 is CIE daylight at 6000K
+	WhiteD60 = illuminants2["D60"]
+	// WhiteD75 is north sky daylight (7500K)
+	WhiteD75 = illuminants2["D75"]
+	// WhiteE is the equal-energy illuminant
+	WhiteE = illuminants2["E"]
+	// WhiteDCI is the DCI-P3 projector white, greenish compared to D65
+	WhiteDCI = illuminants2["DCI"]
+)
+
+// Observer is a CIE standard colorimetric observer.
+type Observer int
+
+const (
+	// Observer2 is the CIE 1931 2° standard observer, used by almost all color spaces.
+	Observer2 Observer = iota
+	// Observer10 is the CIE 1964 10° supplementary standard observer, for large fields.
+	Observer10
+)
+
+// Illuminant returns a standard illuminant for an observer by name, such as
+// "D65", "A" or "F11" (case-insensitive). ACES and DCI are defined by their
+// chromaticity, so they are the same for both observers.
+//
+// Example:
+//   d50, _ := color.Illuminant("D50", color.Observer10)
+//   x, y, z := color.Adapt(0.2, 0.3, 0.4, color.WhiteD65, d50, color.CATBradford)
+func Illuminant(name string, observer Observer) (WhitePoint, bool) {
+	catalog := illuminants2
+	if observer == Observer10 {
+		catalog = illuminants10
+	}
+	w, ok := catalog[strings.ToUpper(name)]
+	return w, ok
+}
+
+// Illuminants returns the names of the illuminants in the catalog, sorted.
+func Illuminants() []string {
+	names := make([]string, 0, len(illuminants2))
+	for name := range illuminants2 {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// illuminants2 and illuminants10 are the catalog for the 2° and 10° observers.
+// A, C and the D series use the ASTM E308 tristimulus values; the others are
+// CIE chromaticities.
+var (
+	illuminants2 = newIlluminantCatalog(map[string][3]float64{
+		"A":   {1.09850, 1, 0.35585},
+		"C":   {0.98074, 1, 1.18232},
+		"D50": whiteD50,
+		"D55": {0.95682, 1, 0.92149},
+		"D65": whiteD65,
+		"D75": {0.94972, 1, 1.22638},
+	}, map[string][2]float64{
+		"B":   {0.34842, 0.35161},
+		"D60": {0.32163, 0.33774},
+		"F1":  {0.31310, 0.33727},
+		"F2":  {0.37208, 0.37529},
+		"F3":  {0.40910, 0.39430},
+		"F4":  {0.44018, 0.40329},
+		"F5":  {0.31379, 0.34531},
+		"F6":  {0.37790, 0.38835},
+		"F7":  {0.31292, 0.32933},
+		"F8":  {0.34588, 0.35875},
+		"F9":  {0.37417, 0.37281},
+		"F10": {0.34609, 0.35986},
+		"F11": {0.38052, 0.37713},
+		"F12": {0.43695, 0.40441},
+	})
+
+	illuminants10 = newIlluminantCatalog(map[string][3]float64{
+		"A":   {1.11144, 1, 0.35200},
+		"C":   {0.97285, 1, 1.16145},
+		"D50": {0.96720, 1, 0.81427},
+		"D55": {0.95799, 1, 0.90926},
+		"D65": {0.94811, 1, 1.07304},
+		"D75": {0.94416, 1, 1.20641},
+	}, map[string][2]float64{
+		"B":   {0.34980, 0.35270},
+		"D60": {0.32299, 0.33928},
+		"F1":  {0.31811, 0.33559},
+		"F2":  {0.37925, 0.36733},
+		"F3":  {0.41761, 0.38324},
+		"F4":  {0.44920, 0.39074},
+		"F5":  {0.31975, 0.34246},
+		"F6":  {0.38660, 0.37847},
+		"F7":  {0.31569, 0.32960},
+		"F8":  {0.34902, 0.35939},
+		"F9":  {0.37829, 0.37045},
+		"F10": {0.35090, 0.35444},
+		"F11": {0.38541, 0.37123},
+		"F12": {0.44256, 0.39717},
+	})
+)
+
+// newIlluminantCatalog builds a catalog from tristimulus values and
+// chromaticities, adding the observer-independent whites.
+func newIlluminantCatalog(xyz map[string][3]float64, xy map[string][2]float64) map[string]WhitePoint {
+	catalog := map[string]WhitePoint{
+		"E":    {Name: "E", X: 1, Y: 1, Z: 1},
+		"ACES": {Name: "ACES", X: whiteACES[0], Y: 1, Z: whiteACES[2]},
+		"DCI":  NewWhitePoint("DCI", 0.314, 0.351),
+	}
+	for name, v := range xyz {
+		catalog[name] = WhitePoint{Name: name, X: v[0], Y: v[1], Z: v[2]}
+	}
+	for name, v := range xy {
+		catalog[name] = NewWhitePoint(name, v[0], v[1])
+	}
+	return catalog
+}
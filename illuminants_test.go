@@ -0,0 +1,94 @@
+package color
+
+import "testing"
+
+func TestIlluminantCatalog(t *testing.T) {
+	tests := []struct {
+		name     string
+		observer Observer
+		x, y     float64
+	}{
+		{"D65", Observer2, 0.31271, 0.32902},
+		{"D50", Observer2, 0.34567, 0.35850},
+		{"A", Observer2, 0.44757, 0.40745},
+		{"C", Observer2, 0.31006, 0.31616},
+		{"F2", Observer2, 0.37208, 0.37529},
+		{"D65", Observer10, 0.31382, 0.33100},
+		{"A", Observer10, 0.45117, 0.40594},
+		{"F11", Observer10, 0.38541, 0.37123},
+		{"E", Observer10, 1.0 / 3, 1.0 / 3},
+		{"DCI", Observer10, 0.314, 0.351},
+		{"ACES", Observer2, 0.32168, 0.33767},
+	}
+	for _, tt := range tests {
+		w, ok := Illuminant(tt.name, tt.observer)
+		if !ok {
+			t.Errorf("%s is not in the catalog", tt.name)
+			continue
+		}
+		if x, y := w.Chromaticity(); !floatNear(x, tt.x, 1e-4) || !floatNear(y, tt.y, 1e-4) {
+			t.Errorf("%s (observer %d) = %.5f, %.5f, want %.5f, %.5f", tt.name, tt.observer, x, y, tt.x, tt.y)
+		}
+		if w.Name != tt.name || w.Y != 1 {
+			t.Errorf("%s = %+v", tt.name, w)
+		}
+	}
+
+	names := Illuminants()
+	if len(names) != 23 {
+		t.Errorf("Illuminants() = %v", names)
+	}
+	for _, name := range names {
+		if _, ok := Illuminant(name, Observer10); !ok {
+			t.Errorf("%s has no 10° entry", name)
+		}
+	}
+
+	if w, ok := Illuminant("d65", Observer2); !ok || w != WhiteD65 {
+		t.Errorf("d65 = %+v, want WhiteD65", w)
+	}
+	if _, ok := Illuminant("D93", Observer2); ok {
+		t.Error("D93 should not be in the catalog")
+	}
+}
+
+func TestNewWhitePoint(t *testing.T) {
+	w := NewWhitePoint("DCI", 0.314, 0.351)
+	if x, y := w.Chromaticity(); !floatNear(x, 0.314, 1e-12) || !floatNear(y, 0.351, 1e-12) {
+		t.Errorf("chromaticity = %g, %g", x, y)
+	}
+	if w != WhiteDCI {
+		t.Errorf("NewWhitePoint = %+v, want %+v", w, WhiteDCI)
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	// Bradford-adapted sRGB red in D50 (Lindbloom)
+	x, y, z := Adapt(0.4124564, 0.2126729, 0.0193339, WhiteD65, WhiteD50, CATBradford)
+	if !floatNear(x, 0.4360747, 1e-4) || !floatNear(y, 0.2225045, 1e-4) || !floatNear(z, 0.0139322, 1e-4) {
+		t.Errorf("sRGB red in D50 = %g, %g, %g", x, y, z)
+	}
+	ax, ay, az := AdaptD65ToD50(0.4124564, 0.2126729, 0.0193339)
+	if x != ax || y != ay || z != az {
+		t.Errorf("Adapt = %g, %g, %g, AdaptD65ToD50 = %g, %g, %g", x, y, z, ax, ay, az)
+	}
+
+	for _, method := range []CAT{CATBradford, CATXYZScaling} {
+		for _, name := range []string{"A", "D50", "F11", "DCI"} {
+			to, _ := Illuminant(name, Observer2)
+
+			// The source white becomes the destination white
+			x, y, z := Adapt(WhiteD65.X, WhiteD65.Y, WhiteD65.Z, WhiteD65, to, method)
+			if !floatNear(x, to.X, 1e-6) || !floatNear(y, to.Y, 1e-6) || !floatNear(z, to.Z, 1e-6) {
+				t.Errorf("%s: D65 white under %s = %g, %g, %g, want %+v", method, name, x, y, z, to)
+			}
+
+			// And back
+			bx, by, bz := Adapt(0.2, 0.3, 0.4, WhiteD65, to, method)
+			bx, by, bz = Adapt(bx, by, bz, to, WhiteD65, method)
+			if !floatNear(bx, 0.2, 1e-6) || !floatNear(by, 0.3, 1e-6) || !floatNear(bz, 0.4, 1e-6) {
+				t.Errorf("%s: round trip through %s = %g, %g, %g", method, name, bx, by, bz)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package color
+
+import "math"
+
+// SDRWhiteLuminance is the luminance in cd/m² of the white of SDR content,
+// Y = 1 in relative XYZ, when it is placed in an HDR signal (ITU-R BT.2408).
+// HDR color models such as Jzazbz convert relative XYZ to absolute with it.
+const SDRWhiteLuminance = 203.0
+
+// Jzazbz represents a color in the Jzazbz color space (Safdar et al., 2017),
+// a perceptually uniform space built on the PQ curve, so it stays uniform from
+// deep shadows to HDR highlights of 10,000 cd/m².
+// Jz is lightness (~0.222 for SDR white, ~1 at 10,000 cd/m²), Az and Bz are
+// red-green and yellow-blue, like OKLAB a and b.
+type Jzazbz struct {
+	Jz, Az, Bz, A_ float64 // A_ is alpha to avoid conflict with method
+}
+
+// NewJzazbz creates a new Jzazbz color.
+func NewJzazbz(jz, az, bz, alpha float64) *Jzazbz {
+	return &Jzazbz{Jz: jz, Az: az, Bz: bz, A_: clamp01(alpha)}
+}
+
+// RGBA converts Jzazbz to RGBA via XYZ.
+func (c *Jzazbz) RGBA() (r, g, b, a float64) {
+	return c.toXYZ().RGBA()
+}
+
+// linearRGBA implements linearColor.
+func (c *Jzazbz) linearRGBA() (r, g, b, a float64) {
+	return c.toXYZ().linearRGBA()
+}
+
+// Alpha implements Color.
+func (c *Jzazbz) Alpha() float64 {
+	return c.A_
+}
+
+// WithAlpha implements Color.
+func (c *Jzazbz) WithAlpha(alpha float64) Color {
+	return &Jzazbz{Jz: c.Jz, Az: c.Az, Bz: c.Bz, A_: clamp01(alpha)}
+}
+
+// AbsoluteXYZ returns the color's XYZ in cd/m².
+func (c *Jzazbz) AbsoluteXYZ() (x, y, z float64) {
+	return jzazbzToAbsoluteXYZ(c.Jz, c.Az, c.Bz)
+}
+
+// toXYZ converts Jzazbz to relative XYZ, with SDR white at Y = 1.
+func (c *Jzazbz) toXYZ() *XYZ {
+	x, y, z := c.AbsoluteXYZ()
+	return &XYZ{X: x / SDRWhiteLuminance, Y: y / SDRWhiteLuminance, Z: z / SDRWhiteLuminance, A: c.A_}
+}
+
+// ToJzazbz converts a Color to Jzazbz. The color's relative luminance is
+// scaled so that Y = 1 is SDRWhiteLuminance; use JzazbzFromAbsoluteXYZ for
+// HDR values in cd/m².
+func ToJzazbz(c Color) *Jzazbz {
+	xyz := ToXYZ(c)
+	jz := JzazbzFromAbsoluteXYZ(xyz.X*SDRWhiteLuminance, xyz.Y*SDRWhiteLuminance, xyz.Z*SDRWhiteLuminance)
+	jz.A_ = xyz.A
+	return jz
+}
+
+// JzazbzFromAbsoluteXYZ converts absolute XYZ (D65, Y in cd/m²) to Jzazbz.
+//
+// Example:
+//   // A 1000-nit highlight and SDR white, on the same scale
+//   highlight := color.JzazbzFromAbsoluteXYZ(950.47, 1000, 1088.83)
+//   white := color.ToJzazbz(color.RGB(1, 1, 1))
+func JzazbzFromAbsoluteXYZ(x, y, z float64) *Jzazbz {
+	jz, az, bz := absoluteXYZToJzazbz(x, y, z)
+	return &Jzazbz{Jz: jz, Az: az, Bz: bz, A_: 1}
+}
+
+// JzCzhz represents a color in JzCzhz, the cylindrical form of Jzazbz.
+// Jz is lightness, Cz is chroma, Hz is hue [0, 360).
+type JzCzhz struct {
+	Jz, Cz, Hz, A_ float64 // A_ is alpha to avoid conflict with method
+}
+
+// NewJzCzhz creates a new JzCzhz color.
+func NewJzCzhz(jz, cz, hz, alpha float64) *JzCzhz {
+	return &JzCzhz{Jz: jz, Cz: math.Max(0, cz), Hz: NormalizeHue(hz), A_: clamp01(alpha)}
+}
+
+// RGBA converts JzCzhz to RGBA via Jzazbz.
+func (c *JzCzhz) RGBA() (r, g, b, a float64) {
+	return c.toJzazbz().RGBA()
+}
+
+// linearRGBA implements linearColor.
+func (c *JzCzhz) linearRGBA() (r, g, b, a float64) {
+	return c.toJzazbz().linearRGBA()
+}
+
+// Alpha implements Color.
+func (c *JzCzhz) Alpha() float64 {
+	return c.A_
+}
+
+// WithAlpha implements Color.
+func (c *JzCzhz) WithAlpha(alpha float64) Color {
+	return &JzCzhz{Jz: c.Jz, Cz: c.Cz, Hz: c.Hz, A_: clamp01(alpha)}
+}
+
+// toJzazbz converts JzCzhz to Jzazbz.
+func (c *JzCzhz) toJzazbz() *Jzazbz {
+	rad := c.Hz * math.Pi / 180
+	return &Jzazbz{Jz: c.Jz, Az: c.Cz * math.Cos(rad), Bz: c.Cz * math.Sin(rad), A_: c.A_}
+}
+
+// ToJzCzhz converts a Color to JzCzhz.
+func ToJzCzhz(c Color) *JzCzhz {
+	return ToJzazbz(c).toJzCzhz()
+}
+
+// toJzCzhz converts Jzazbz to JzCzhz.
+func (c *Jzazbz) toJzCzhz() *JzCzhz {
+	cz := math.Sqrt(c.Az*c.Az + c.Bz*c.Bz)
+	hz := normalizeHue(math.Atan2(c.Bz, c.Az) * 180 / math.Pi)
+	return &JzCzhz{Jz: c.Jz, Cz: cz, Hz: hz, A_: c.A_}
+}
+
+// DeltaEJz calculates the color difference in JzCzhz (Safdar et al., 2017).
+// Unlike the other metrics, it stays meaningful for HDR colors.
+// Values around 0.002 are just noticeable.
+func DeltaEJz(c1, c2 Color) float64 {
+	a, b := ToJzCzhz(c1), ToJzCzhz(c2)
+
+	dj := a.Jz - b.Jz
+	dc := a.Cz - b.Cz
+	dh := 2 * math.Sqrt(a.Cz*b.Cz) * math.Sin((a.Hz-b.Hz)*math.Pi/360)
+
+	return math.Sqrt(dj*dj + dc*dc + dh*dh)
+}
+
+// Jzazbz constants
+const (
+	jzB  = 1.15
+	jzG  = 0.66
+	jzD  = -0.56
+	jzD0 = 1.6295499532821566e-11
+	// PQ exponent for Jzazbz; larger than ST 2084's m2
+	jzP = 1.7 * 2523.0 / 32
+)
+
+// jzLMS converts modified XYZ (X', Y', Z) to LMS.
+var jzLMS = [9]float64{
+	0.41478972, 0.579999, 0.0146480,
+	-0.2015100, 1.120649, 0.0531008,
+	-0.0166008, 0.264800, 0.6684799,
+}
+
+// jzIab converts PQ-encoded LMS to Iz, az, bz.
+var jzIab = [9]float64{
+	0.5, 0.5, 0,
+	3.524000, -4.066708, 0.542708,
+	0.199076, 1.096799, -1.295875,
+}
+
+var (
+	jzLMSInv = invert3(jzLMS)
+	jzIabInv = invert3(jzIab)
+)
+
+// absoluteXYZToJzazbz converts absolute XYZ (cd/m²) to Jzazbz.
+func absoluteXYZToJzazbz(x, y, z float64) (jz, az, bz float64) {
+	xp := jzB*x - (jzB-1)*z
+	yp := jzG*y - (jzG-1)*x
+
+	lms := mulMatrix3(jzLMS, xp, yp, z)
+	for i, v := range lms {
+		lms[i] = jzPQEncode(v / 10000)
+	}
+
+	iab := mulMatrix3(jzIab, lms[0], lms[1], lms[2])
+	iz := iab[0]
+	jz = (1+jzD)*iz/(1+jzD*iz) - jzD0
+	return jz, iab[1], iab[2]
+}
+
+// jzazbzToAbsoluteXYZ converts Jzazbz to absolute XYZ (cd/m²).
+func jzazbzToAbsoluteXYZ(jz, az, bz float64) (x, y, z float64) {
+	j := jz + jzD0
+	iz := j / (1 + jzD - jzD*j)
+
+	lms := mulMatrix3(jzIabInv, iz, az, bz)
+	for i, v := range lms {
+		lms[i] = 10000 * jzPQDecode(v)
+	}
+
+	xyz := mulMatrix3(jzLMSInv, lms[0], lms[1], lms[2])
+	xp, yp, z := xyz[0], xyz[1], xyz[2]
+	x = (xp + (jzB-1)*z) / jzB
+	y = (yp + (jzG-1)*x) / jzG
+	return x, y, z
+}
+
+// jzPQEncode is the PQ curve with Jzazbz's exponent, for v = L/10000.
+// Negative values are mirrored so out-of-gamut colors survive a round trip.
+func jzPQEncode(v float64) float64 {
+	const c1, c2, c3, n = 3424.0 / 4096, 2413.0 / 128, 2392.0 / 128, 2610.0 / 16384
+	vn := math.Pow(math.Abs(v), n)
+	return math.Copysign(math.Pow((c1+c2*vn)/(1+c3*vn), jzP), v)
+}
+
+// jzPQDecode inverts jzPQEncode.
+func jzPQDecode(e float64) float64 {
+	const c1, c2, c3, n = 3424.0 / 4096, 2413.0 / 128, 2392.0 / 128, 2610.0 / 16384
+	ep := math.Pow(math.Abs(e), 1/jzP)
+	v := math.Pow(math.Max(0, (c1-ep)/(c3*ep-c2)), 1/n)
+	return math.Copysign(v, e)
+}
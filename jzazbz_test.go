@@ -0,0 +1,106 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJzazbzReference(t *testing.T) {
+	// colour-science XYZ_to_Jzazbz, absolute XYZ
+	jz := JzazbzFromAbsoluteXYZ(0.20654008, 0.12197225, 0.05136952)
+	if !floatNear(jz.Jz, 0.00535048, 1e-8) || !floatNear(jz.Az, 0.00924302, 1e-8) || !floatNear(jz.Bz, 0.00526007, 1e-8) {
+		t.Errorf("Jzazbz = %+v", jz)
+	}
+
+	// SDR white at 203 cd/m² and sRGB red, as in color.js
+	white := ToJzazbz(RGB(1, 1, 1))
+	if !floatNear(white.Jz, 0.2221, 1e-4) || math.Hypot(white.Az, white.Bz) > 5e-4 {
+		t.Errorf("white = %+v", white)
+	}
+	red := ToJzazbz(RGB(1, 0, 0))
+	if !floatNear(red.Jz, 0.1344, 1e-4) || !floatNear(red.Az, 0.1179, 1e-4) || !floatNear(red.Bz, 0.1119, 1e-4) {
+		t.Errorf("red = %+v", red)
+	}
+}
+
+func TestJzazbzRoundTrip(t *testing.T) {
+	colors := []Color{
+		RGB(1, 0, 0), RGB(0.2, 0.4, 0.6), RGB(0, 0, 0), RGB(0.001, 0.002, 0.003),
+		NewSpaceColor(Rec2020Space, []float64{0, 1, 0}, 0.5),
+	}
+	for _, c := range colors {
+		want := ToXYZ(c)
+		got := ToJzazbz(c).toXYZ()
+		if !floatNear(got.X, want.X, 1e-9) || !floatNear(got.Y, want.Y, 1e-9) || !floatNear(got.Z, want.Z, 1e-9) {
+			t.Errorf("round trip of %v = %v, want %v", c, got, want)
+		}
+		if got.A != c.Alpha() {
+			t.Errorf("alpha = %g, want %g", got.A, c.Alpha())
+		}
+		if d := DeltaEOK(ToJzCzhz(c), c); d > 1e-6 {
+			t.Errorf("JzCzhz round trip of %v differs by %g", c, d)
+		}
+	}
+
+	// Absolute luminance well above SDR white
+	x, y, z := JzazbzFromAbsoluteXYZ(950.47, 1000, 1088.83).AbsoluteXYZ()
+	if !floatNear(x, 950.47, 1e-6) || !floatNear(y, 1000, 1e-6) || !floatNear(z, 1088.83, 1e-6) {
+		t.Errorf("AbsoluteXYZ() = %g %g %g", x, y, z)
+	}
+}
+
+func TestJzazbzHDR(t *testing.T) {
+	white := ToJzazbz(RGB(1, 1, 1))
+	hdr := JzazbzFromAbsoluteXYZ(950.47, 1000, 1088.83)
+	peak := JzazbzFromAbsoluteXYZ(9504.7, 10000, 10888.3)
+	if !(white.Jz < hdr.Jz && hdr.Jz < peak.Jz) {
+		t.Errorf("Jz not increasing: %g, %g, %g", white.Jz, hdr.Jz, peak.Jz)
+	}
+	if !floatNear(peak.Jz, 1, 0.02) {
+		t.Errorf("Jz at 10,000 cd/m² = %g, want about 1", peak.Jz)
+	}
+}
+
+func TestJzazbzSpaces(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		space Space
+	}{{"jzazbz", JzazbzSpace}, {"jzczhz", JzCzhzSpace}} {
+		space, ok := GetSpace(tt.name)
+		if !ok || space != tt.space {
+			t.Errorf("%s is not registered", tt.name)
+			continue
+		}
+		c := NewSpaceColor(SRGBSpace, []float64{0.9, 0.5, 0.1}, 1).ConvertTo(space)
+		back := c.ConvertTo(SRGBSpace).Channels()
+		for i, v := range []float64{0.9, 0.5, 0.1} {
+			if !floatNear(back[i], v, 1e-6) {
+				t.Errorf("%s round trip = %v", tt.name, back)
+				break
+			}
+		}
+	}
+
+	jch := NewSpaceColor(SRGBSpace, []float64{1, 0, 0}, 1).ConvertTo(JzCzhzSpace).Channels()
+	want := ToJzCzhz(RGB(1, 0, 0))
+	if !floatNear(jch[1], want.Cz, 1e-6) || !floatNear(jch[2], want.Hz, 1e-4) {
+		t.Errorf("JzCzhzSpace red = %v, ToJzCzhz = %+v", jch, want)
+	}
+}
+
+func TestDeltaEJz(t *testing.T) {
+	a := RGB(0.5, 0.2, 0.2)
+	if d := DeltaEJz(a, a); d != 0 {
+		t.Errorf("DeltaEJz(a, a) = %g", d)
+	}
+	small, large := DeltaEJz(a, RGB(0.51, 0.2, 0.2)), DeltaEJz(a, RGB(0.2, 0.5, 0.2))
+	if small <= 0 || small >= large {
+		t.Errorf("DeltaEJz small = %g, large = %g", small, large)
+	}
+
+	// Symmetric
+	b := RGB(0.1, 0.3, 0.8)
+	if !floatNear(DeltaEJz(a, b), DeltaEJz(b, a), 1e-12) {
+		t.Error("DeltaEJz is not symmetric")
+	}
+}
@@ -24,9 +24,14 @@ func (c *LAB) RGBA() (r, g, b, a float64) {
 	return xyz.RGBA()
 }
 
+// linearRGBA implements linearColor.
+func (c *LAB) linearRGBA() (r, g, b, a float64) {
+	return c.toXYZ().linearRGBA()
+}
+
 // Alpha implements Color.
 func (c *LAB) Alpha() float64 {
-	return c.A_
+	return resolveNone(c.A_)
 }
 
 // WithAlpha implements Color.
@@ -34,32 +39,37 @@ func (c *LAB) WithAlpha(alpha float64) Color {
 	return &LAB{L: c.L, A: c.A, B: c.B, A_: clamp01(alpha)}
 }
 
+// String returns a CSS lab() representation.
+func (c *LAB) String() string {
+	return CSSString(c)
+}
+
 // toXYZ converts LAB to XYZ.
 func (c *LAB) toXYZ() *XYZ {
-	// D65 white point
-	const (
-		xn = 0.95047
-		yn = 1.00000
-		zn = 1.08883
-	)
+	x, y, z := labToXYZ(resolveNone(c.L), resolveNone(c.A), resolveNone(c.B), whiteD65)
+	return &XYZ{X: x, Y: y, Z: z, A: c.Alpha()}
+}
+
+// labToXYZ converts LAB relative to a white point to XYZ.
+func labToXYZ(l, a, b float64, white [3]float64) (x, y, z float64) {
+	xn, yn, zn := white[0], white[1], white[2]
 
 	// Convert LAB to XYZ
-	fy := (c.L + 16) / 116
-	fx := c.A/500 + fy
-	fz := fy - c.B/200
+	fy := (l + 16) / 116
+	fx := a/500 + fy
+	fz := fy - b/200
 
 	// Calculate x, y, z
-	var x, y, z float64
 	if fx3 := fx * fx * fx; fx3 > 0.008856 {
 		x = xn * fx3
 	} else {
 		x = (fx - 16.0/116.0) * 3 * 0.008856 * xn
 	}
 
-	if c.L > 8 {
-		y = yn * math.Pow((c.L+16)/116, 3)
+	if l > 8 {
+		y = yn * math.Pow((l+16)/116, 3)
 	} else {
-		y = c.L / 903.3 * yn
+		y = l / 903.3 * yn
 	}
 
 	if fz3 := fz * fz * fz; fz3 > 0.008856 {
@@ -68,7 +78,7 @@ func (c *LAB) toXYZ() *XYZ {
 		z = (fz - 16.0/116.0) * 3 * 0.008856 * zn
 	}
 
-	return &XYZ{X: x, Y: y, Z: z, A: c.A_}
+	return x, y, z
 }
 
 // ToLAB converts an RGBA color to LAB.
@@ -79,28 +89,21 @@ func ToLAB(c Color) *LAB {
 
 // toLAB converts XYZ to LAB.
 func (c *XYZ) toLAB() *LAB {
-	// D65 white point
-	const (
-		xn = 0.95047
-		yn = 1.00000
-		zn = 1.08883
-	)
+	l, a, b := xyzToLAB(c.X, c.Y, c.Z, whiteD65)
+	return &LAB{L: l, A: a, B: b, A_: c.A}
+}
 
+// xyzToLAB converts XYZ to LAB relative to a white point.
+func xyzToLAB(x, y, z float64, white [3]float64) (l, a, b float64) {
 	// Normalize by white point
-	x := c.X / xn
-	y := c.Y / yn
-	z := c.Z / zn
-
-	// Convert to LAB
-	fx := labF(x)
-	fy := labF(y)
-	fz := labF(z)
-
-	l := 116*fy - 16
-	a := 500 * (fx - fy)
-	b := 200 * (fy - fz)
-
-	return &LAB{L: l, A: a, B: b, A_: c.A}
+	fx := labF(x / white[0])
+	fy := labF(y / white[1])
+	fz := labF(z / white[2])
+
+	l = 116*fy - 16
+	a = 500 * (fx - fy)
+	b = 200 * (fy - fz)
+	return l, a, b
 }
 
 // labF is the helper function for LAB conversion.
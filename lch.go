@@ -24,9 +24,14 @@ func (c *LCH) RGBA() (r, g, b, a float64) {
 	return lab.RGBA()
 }
 
+// linearRGBA implements linearColor.
+func (c *LCH) linearRGBA() (r, g, b, a float64) {
+	return c.toLAB().linearRGBA()
+}
+
 // Alpha implements Color.
 func (c *LCH) Alpha() float64 {
-	return c.A_
+	return resolveNone(c.A_)
 }
 
 // WithAlpha implements Color.
@@ -34,12 +39,17 @@ func (c *LCH) WithAlpha(alpha float64) Color {
 	return &LCH{L: c.L, C: c.C, H: c.H, A_: clamp01(alpha)}
 }
 
+// String returns a CSS lch() representation.
+func (c *LCH) String() string {
+	return CSSString(c)
+}
+
 // toLAB converts LCH to LAB.
 func (c *LCH) toLAB() *LAB {
-	rad := c.H * math.Pi / 180
-	a := c.C * math.Cos(rad)
-	b := c.C * math.Sin(rad)
-	return &LAB{L: c.L, A: a, B: b, A_: c.A_}
+	rad := resolveNone(c.H) * math.Pi / 180
+	a := resolveNone(c.C) * math.Cos(rad)
+	b := resolveNone(c.C) * math.Sin(rad)
+	return &LAB{L: resolveNone(c.L), A: a, B: b, A_: c.Alpha()}
 }
 
 // ToLCH converts an RGBA color to LCH.
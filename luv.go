@@ -25,6 +25,11 @@ func (c *LUV) RGBA() (r, g, b, a float64) {
 	return xyz.RGBA()
 }
 
+// linearRGBA implements linearColor.
+func (c *LUV) linearRGBA() (r, g, b, a float64) {
+	return c.toXYZ().linearRGBA()
+}
+
 // Alpha implements Color.
 func (c *LUV) Alpha() float64 {
 	return c.A_
@@ -138,6 +143,11 @@ func (c *LCHuv) RGBA() (r, g, b, a float64) {
 	return luv.RGBA()
 }
 
+// linearRGBA implements linearColor.
+func (c *LCHuv) linearRGBA() (r, g, b, a float64) {
+	return c.toLUV().linearRGBA()
+}
+
 // Alpha implements Color.
 func (c *LCHuv) Alpha() float64 {
 	return c.A_
@@ -0,0 +1,59 @@
+package color
+
+// mulMatrix3 multiplies a row-major 3x3 matrix by a vector.
+func mulMatrix3(m [9]float64, x, y, z float64) [3]float64 {
+	return [3]float64{
+		m[0]*x + m[1]*y + m[2]*z,
+		m[3]*x + m[4]*y + m[5]*z,
+		m[6]*x + m[7]*y + m[8]*z,
+	}
+}
+
+// invert3 inverts a row-major 3x3 matrix.
+func invert3(m [9]float64) [9]float64 {
+	det := m[0]*(m[4]*m[8]-m[5]*m[7]) - m[1]*(m[3]*m[8]-m[5]*m[6]) + m[2]*(m[3]*m[7]-m[4]*m[6])
+	return [9]float64{
+		(m[4]*m[8] - m[5]*m[7]) / det,
+		(m[2]*m[7] - m[1]*m[8]) / det,
+		(m[1]*m[5] - m[2]*m[4]) / det,
+		(m[5]*m[6] - m[3]*m[8]) / det,
+		(m[0]*m[8] - m[2]*m[6]) / det,
+		(m[2]*m[3] - m[0]*m[5]) / det,
+		(m[3]*m[7] - m[4]*m[6]) / det,
+		(m[1]*m[6] - m[0]*m[7]) / det,
+		(m[0]*m[4] - m[1]*m[3]) / det,
+	}
+}
+
+// mulMatrices3 multiplies two row-major 3x3 matrices, a × b.
+func mulMatrices3(a, b [9]float64) [9]float64 {
+	var m [9]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i*3+j] = a[i*3]*b[j] + a[i*3+1]*b[3+j] + a[i*3+2]*b[6+j]
+		}
+	}
+	return m
+}
+
+// rgbToXYZFromPrimaries computes the matrix from linear RGB to XYZ for
+// red, green and blue primaries given as xy chromaticities, scaled so
+// that RGB (1, 1, 1) is the white point (XYZ with Y = 1).
+func rgbToXYZFromPrimaries(r, g, b [2]float64, white [3]float64) [9]float64 {
+	xyz := func(c [2]float64) [3]float64 {
+		return [3]float64{c[0] / c[1], 1, (1 - c[0] - c[1]) / c[1]}
+	}
+	pr, pg, pb := xyz(r), xyz(g), xyz(b)
+	m := [9]float64{
+		pr[0], pg[0], pb[0],
+		pr[1], pg[1], pb[1],
+		pr[2], pg[2], pb[2],
+	}
+
+	// Scale each primary so that together they add up to the white
+	scale := mulMatrix3(invert3(m), white[0], white[1], white[2])
+	for i := 0; i < 9; i++ {
+		m[i] *= scale[i%3]
+	}
+	return m
+}
@@ -76,6 +76,17 @@ func getMetadataForSpace(name string) *SpaceMetadata {
 			Family:                    "RGB",
 			IsRGB:                     true,
 			IsHDR:                     false,
+			WhitePoint:                "DCI",
+			GamutVolumeRelativeToSRGB: 1.26,
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "dci-p3-d65":
+		return &SpaceMetadata{
+			Name:                      "dci-p3-d65",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     false,
 			WhitePoint:                "D65",
 			GamutVolumeRelativeToSRGB: 1.26,
 			IsPerceptuallyUniform:     false,
@@ -125,6 +136,83 @@ func getMetadataForSpace(name string) *SpaceMetadata {
 			IsPerceptuallyUniform:     false,
 			IsPolar:                   false,
 		}
+	case "rec2100-pq":
+		return &SpaceMetadata{
+			Name:                      "rec2100-pq",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.73, // Rec. 2020 primaries
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "rec2100-hlg":
+		return &SpaceMetadata{
+			Name:                      "rec2100-hlg",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.73, // Rec. 2020 primaries
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "rec2100-linear":
+		return &SpaceMetadata{
+			Name:                      "rec2100-linear",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.73, // Rec. 2020 primaries
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "aces2065-1":
+		return &SpaceMetadata{
+			Name:                      "aces2065-1",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "ACES",
+			GamutVolumeRelativeToSRGB: 3.23, // AP0 encloses the spectral locus
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "acescg":
+		return &SpaceMetadata{
+			Name:                      "acescg",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "ACES",
+			GamutVolumeRelativeToSRGB: 1.84, // AP1
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "acescc":
+		return &SpaceMetadata{
+			Name:                      "acescc",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "ACES",
+			GamutVolumeRelativeToSRGB: 1.84, // AP1
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "acescct":
+		return &SpaceMetadata{
+			Name:                      "acescct",
+			Family:                    "RGB",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "ACES",
+			GamutVolumeRelativeToSRGB: 1.84, // AP1
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
 	case "OKLCH":
 		return &SpaceMetadata{
 			Name:                      "OKLCH",
@@ -136,6 +224,72 @@ func getMetadataForSpace(name string) *SpaceMetadata {
 			IsPerceptuallyUniform:     true,
 			IsPolar:                   true,
 		}
+	case "CAM16-UCS":
+		return &SpaceMetadata{
+			Name:                      "CAM16-UCS",
+			Family:                    "CAM16",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   false,
+		}
+	case "HCT":
+		return &SpaceMetadata{
+			Name:                      "HCT",
+			Family:                    "CAM16",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   true,
+		}
+	case "Jzazbz":
+		return &SpaceMetadata{
+			Name:                      "Jzazbz",
+			Family:                    "Jzazbz",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   false,
+		}
+	case "JzCzhz":
+		return &SpaceMetadata{
+			Name:                      "JzCzhz",
+			Family:                    "Jzazbz",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   true,
+		}
+	case "ICtCp":
+		return &SpaceMetadata{
+			Name:                      "ICtCp",
+			Family:                    "ICtCp",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   false,
+		}
+	case "ICtCp-HLG":
+		return &SpaceMetadata{
+			Name:                      "ICtCp-HLG",
+			Family:                    "ICtCp",
+			IsRGB:                     false,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 0, // Not applicable for non-RGB spaces
+			IsPerceptuallyUniform:     true,
+			IsPolar:                   false,
+		}
 	case "c-log":
 		return &SpaceMetadata{
 			Name:                      "c-log",
@@ -147,6 +301,83 @@ func getMetadataForSpace(name string) *SpaceMetadata {
 			IsPerceptuallyUniform:     false,
 			IsPolar:                   false,
 		}
+	case "arri-logc4":
+		return &SpaceMetadata{
+			Name:                      "arri-logc4",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.68, // ARRI Wide Gamut 4
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "canon-log2":
+		return &SpaceMetadata{
+			Name:                      "canon-log2",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.56, // Cinema Gamut
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "canon-log3":
+		return &SpaceMetadata{
+			Name:                      "canon-log3",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.56, // Cinema Gamut
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "s-log3-cine":
+		return &SpaceMetadata{
+			Name:                      "s-log3-cine",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.68, // S-Gamut3.Cine
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "davinci-intermediate":
+		return &SpaceMetadata{
+			Name:                      "davinci-intermediate",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 2.30, // DaVinci Wide Gamut
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "apple-log":
+		return &SpaceMetadata{
+			Name:                      "apple-log",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.73, // Rec. 2020 primaries
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
+	case "f-log2":
+		return &SpaceMetadata{
+			Name:                      "f-log2",
+			Family:                    "RGB-LOG",
+			IsRGB:                     true,
+			IsHDR:                     true,
+			WhitePoint:                "D65",
+			GamutVolumeRelativeToSRGB: 1.73, // F-Gamut (Rec. 2020 primaries)
+			IsPerceptuallyUniform:     false,
+			IsPolar:                   false,
+		}
 	case "s-log3":
 		return &SpaceMetadata{
 			Name:                      "s-log3",
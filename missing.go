@@ -0,0 +1,102 @@
+package color
+
+import "math"
+
+// Missing components
+//
+// CSS Color 4 lets any component be the keyword none, e.g. "oklch(0.7 0.1 none)".
+// ParseColor stores such a missing component as NaN in the color's struct field.
+// A missing component behaves as zero when the color is displayed or converted,
+// but when colors are mixed or interpolated it takes the value of the other
+// color instead ("carry-forward"), including across color spaces for analogous
+// components (for example the hue of HSL and the hue of OKLCH).
+
+// resolveNone returns 0 for a missing component (stored as NaN) and v otherwise.
+func resolveNone(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return v
+}
+
+// componentKind identifies a color component for carrying missing components
+// between color spaces. Components of the same kind are "analogous" in the
+// sense of CSS Color 4; every other component has a kind of its own.
+type componentKind int
+
+const (
+	kindRed          componentKind = iota + 1 // r, x
+	kindGreen                                 // g, y
+	kindBlue                                  // b, z
+	kindLightness                             // L of HSL, LAB, LCH, OKLAB and OKLCH
+	kindColorfulness                          // C of LCH and OKLCH, S of HSL
+	kindHue                                   // H
+	kindOpponentA                             // a of LAB and OKLAB
+	kindOpponentB                             // b of LAB and OKLAB
+	kindWhiteness                             // W of HWB
+	kindBlackness                             // B of HWB
+	kindHSVSaturation                         // S of HSV
+	kindValue                                 // V of HSV
+)
+
+// rawComponents returns the stored channels and alpha of c, with missing
+// components left as NaN, and the kind of each channel.
+// ok is false for color types that cannot hold missing components.
+func rawComponents(c Color) (values [4]float64, kinds [3]componentKind, ok bool) {
+	switch v := c.(type) {
+	case *RGBA:
+		return [4]float64{v.R, v.G, v.B, v.A}, [3]componentKind{kindRed, kindGreen, kindBlue}, true
+	case *XYZ:
+		return [4]float64{v.X, v.Y, v.Z, v.A}, [3]componentKind{kindRed, kindGreen, kindBlue}, true
+	case *HSL:
+		return [4]float64{v.H, v.S, v.L, v.A}, [3]componentKind{kindHue, kindColorfulness, kindLightness}, true
+	case *HSV:
+		return [4]float64{v.H, v.S, v.V, v.A}, [3]componentKind{kindHue, kindHSVSaturation, kindValue}, true
+	case *HWB:
+		return [4]float64{v.H, v.W, v.B, v.A}, [3]componentKind{kindHue, kindWhiteness, kindBlackness}, true
+	case *LAB:
+		return [4]float64{v.L, v.A, v.B, v.A_}, [3]componentKind{kindLightness, kindOpponentA, kindOpponentB}, true
+	case *LCH:
+		return [4]float64{v.L, v.C, v.H, v.A_}, [3]componentKind{kindLightness, kindColorfulness, kindHue}, true
+	case *OKLAB:
+		return [4]float64{v.L, v.A, v.B, v.A_}, [3]componentKind{kindLightness, kindOpponentA, kindOpponentB}, true
+	case *OKLCH:
+		return [4]float64{v.L, v.C, v.H, v.A_}, [3]componentKind{kindLightness, kindColorfulness, kindHue}, true
+	}
+	return values, kinds, false
+}
+
+// missingIn reports which components of c are missing once c is converted to
+// a space whose channels have the given kinds. The result holds the three
+// channels followed by alpha.
+func missingIn(c Color, target [3]componentKind) (missing [4]bool) {
+	values, kinds, ok := rawComponents(c)
+	if !ok {
+		return missing
+	}
+
+	for i, want := range target {
+		for j, kind := range kinds {
+			if kind == want && math.IsNaN(values[j]) {
+				missing[i] = true
+			}
+		}
+	}
+	missing[3] = math.IsNaN(values[3])
+	return missing
+}
+
+// HasMissing reports whether any component of c, including alpha, is missing
+// (the CSS none keyword).
+func HasMissing(c Color) bool {
+	values, _, ok := rawComponents(c)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
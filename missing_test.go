@@ -0,0 +1,127 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNone(t *testing.T) {
+	c, err := ParseColor("oklch(0.7 0.1 none)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	oklch := c.(*OKLCH)
+	if !math.IsNaN(oklch.H) {
+		t.Errorf("Missing hue should be stored as NaN, got %f", oklch.H)
+	}
+	if !HasMissing(c) {
+		t.Error("HasMissing should report the missing hue")
+	}
+
+	// Missing components render as zero
+	want := NewOKLCH(0.7, 0.1, 0, 1)
+	r1, g1, b1, a1 := c.RGBA()
+	r2, g2, b2, a2 := want.RGBA()
+	if !rgbaEqual(r1, g1, b1, a1, r2, g2, b2, a2) {
+		t.Errorf("none should render as 0: got (%f %f %f %f), want (%f %f %f %f)", r1, g1, b1, a1, r2, g2, b2, a2)
+	}
+
+	// Conversions never leak NaN
+	for _, v := range []float64{ToOKLCH(c).H, ToOKLAB(c).A, ToXYZ(c).X, ToLAB(c).L, DeltaEOK(c, want)} {
+		if math.IsNaN(v) {
+			t.Error("Conversion of a color with missing components produced NaN")
+		}
+	}
+}
+
+func TestParseNoneEverywhere(t *testing.T) {
+	inputs := []string{
+		"rgb(none 0 0)",
+		"rgb(255 0 0 / none)",
+		"hsl(none 50% 50%)",
+		"hwb(none 10% 10%)",
+		"lab(none 20 30)",
+		"lch(50 none 30)",
+		"oklab(0.5 none none)",
+		"oklch(none none none / none)",
+		"color(xyz none 0.5 0.5)",
+		"rgb(from red r none b)",
+	}
+
+	for _, input := range inputs {
+		c, err := ParseColor(input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", input, err)
+			continue
+		}
+		r, g, b, a := c.RGBA()
+		for _, v := range []float64{r, g, b, a, c.Alpha()} {
+			if math.IsNaN(v) {
+				t.Errorf("ParseColor(%q).RGBA() produced NaN", input)
+			}
+		}
+	}
+}
+
+func TestMixCarriesMissingForward(t *testing.T) {
+	// A missing hue takes the other color's hue instead of 0
+	gray, _ := ParseColor("oklch(0.5 0 none)")
+	blue := NewOKLCH(0.5, 0.2, 260, 1)
+	mixed := MixInSpace(gray, blue, 0.5, GradientOKLCH).(*OKLCH)
+	if abs(mixed.H-260) > 1e-9 {
+		t.Errorf("Hue = %f, want 260 (carried forward)", mixed.H)
+	}
+	if abs(mixed.C-0.1) > 1e-9 {
+		t.Errorf("Chroma = %f, want 0.1 (interpolated)", mixed.C)
+	}
+
+	// Missing in both stays missing
+	a, _ := ParseColor("oklch(0.2 0.1 none)")
+	b, _ := ParseColor("oklch(0.8 0.1 none)")
+	mixed = MixInSpace(a, b, 0.5, GradientOKLCH).(*OKLCH)
+	if !math.IsNaN(mixed.H) {
+		t.Errorf("Hue missing in both colors should stay missing, got %f", mixed.H)
+	}
+	if abs(mixed.L-0.5) > 1e-9 {
+		t.Errorf("Lightness = %f, want 0.5", mixed.L)
+	}
+
+	// Missing alpha carries forward too
+	a, _ = ParseColor("rgb(255 0 0 / none)")
+	if alpha := MixInSpace(a, NewRGBA(0, 0, 1, 0.4), 0.5, GradientRGB).Alpha(); abs(alpha-0.4) > 1e-9 {
+		t.Errorf("Alpha = %f, want 0.4 (carried forward)", alpha)
+	}
+}
+
+func TestMixCarriesAnalogousComponents(t *testing.T) {
+	// The hue of HSL is analogous to the hue of OKLCH
+	a, _ := ParseColor("hsl(none 0% 50%)")
+	b := NewOKLCH(0.6, 0.15, 140, 1)
+	mixed := MixInSpace(a, b, 0.5, GradientOKLCH).(*OKLCH)
+	if abs(mixed.H-140) > 1e-9 {
+		t.Errorf("Hue = %f, want 140 (carried forward from HSL none)", mixed.H)
+	}
+
+	// Lightness of OKLAB is analogous to lightness of LCH
+	a, _ = ParseColor("oklab(none 0 0)")
+	lch := MixInSpace(a, NewLCH(70, 20, 40, 1), 0.5, GradientLCH).(*LCH)
+	if abs(lch.L-70) > 1e-4 {
+		t.Errorf("Lightness = %f, want 70 (carried forward)", lch.L)
+	}
+
+	// HWB whiteness has no analogue in OKLAB, so it is not carried forward
+	a, _ = ParseColor("hwb(0 none 0%)")
+	if missing := missingIn(a, gradientModelOf(GradientOKLAB).kinds); missing != [4]bool{} {
+		t.Errorf("Unexpected missing components %v", missing)
+	}
+}
+
+func TestColorMixWithNone(t *testing.T) {
+	c, err := ParseColor("color-mix(in oklch, oklch(0.6 0.1 none), oklch(0.6 0.1 200))")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if h := c.(*OKLCH).H; abs(h-200) > 1e-9 {
+		t.Errorf("Hue = %f, want 200", h)
+	}
+}
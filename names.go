@@ -0,0 +1,173 @@
+package color
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Dictionary is a named set of colors that ParseColorWithOptions can look names up in.
+// Names are matched case-insensitively, with surrounding whitespace trimmed and
+// runs of inner whitespace treated as a single space.
+type Dictionary struct {
+	name   string
+	colors map[string]Color
+	names  []string // normalized, sorted
+
+	indexOnce sync.Once
+	index     *okIndex // built on first use
+}
+
+// NameMatch is a result of a nearest-color or name search.
+type NameMatch struct {
+	Name     string
+	Color    Color
+	Distance float64 // Color difference, or text dissimilarity (0-1) for Search
+}
+
+// NewDictionary creates a dictionary from a map of names to colors.
+//
+// Example:
+//   brand := color.NewDictionary("brand", map[string]color.Color{
+//       "brand-blue": color.RGB(0.1, 0.3, 0.9),
+//   })
+//   c, _ := color.ParseColorWithOptions("brand-blue", color.ParseOptions{
+//       Names: []*color.Dictionary{brand, color.CSSNames},
+//   })
+func NewDictionary(name string, colors map[string]Color) *Dictionary {
+	d := &Dictionary{name: name, colors: make(map[string]Color, len(colors))}
+	for n, c := range colors {
+		key := normalizeColorName(n)
+		if _, dup := d.colors[key]; !dup {
+			d.names = append(d.names, key)
+		}
+		d.colors[key] = c
+	}
+	sort.Strings(d.names)
+	return d
+}
+
+// CSSNames holds the CSS named colors that ParseColor recognizes.
+var CSSNames = NewDictionary("css", namedColors)
+
+// Name returns the dictionary's name, e.g. "css".
+func (d *Dictionary) Name() string {
+	return d.name
+}
+
+// Lookup returns the color with the given name.
+// The result is a copy, so callers may modify it.
+func (d *Dictionary) Lookup(name string) (Color, bool) {
+	c, ok := d.colors[normalizeColorName(name)]
+	if !ok {
+		return nil, false
+	}
+	return c.WithAlpha(c.Alpha()), true
+}
+
+// Names returns the normalized names in the dictionary, sorted.
+func (d *Dictionary) Names() []string {
+	names := make([]string, len(d.names))
+	copy(names, d.names)
+	return names
+}
+
+// Len returns the number of names in the dictionary.
+func (d *Dictionary) Len() int {
+	return len(d.names)
+}
+
+// NameOf returns the name of the color in the dictionary that equals c at
+// 8-bit precision, as hex colors are compared, including alpha. When several
+// names share a color ("gray" and "grey"), the first in sorted order is returned.
+func (d *Dictionary) NameOf(c Color) (string, bool) {
+	if !InGamut(c) {
+		return "", false
+	}
+	key := byteKey(c)
+	for _, name := range d.names {
+		if byteKey(d.colors[name]) == key {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Nearest returns the name of the dictionary color closest to c under metric,
+// and its distance. Alpha is ignored, and fully transparent entries are skipped.
+// It returns "" and +Inf if the dictionary has no opaque colors.
+//
+// A nil metric means DeltaEOK and uses the dictionary's OKLab index, which is
+// much faster than scanning for large dictionaries such as X11Names.
+func (d *Dictionary) Nearest(c Color, metric DeltaEFunc) (string, float64) {
+	if metric == nil {
+		matches := d.NearestN(c, 1)
+		if len(matches) == 0 {
+			return "", math.Inf(1)
+		}
+		return matches[0].Name, matches[0].Distance
+	}
+
+	best, bestDist := "", math.Inf(1)
+	for _, name := range d.names {
+		candidate := d.colors[name]
+		if candidate.Alpha() == 0 {
+			continue
+		}
+		if dist := metric(c, candidate); dist < bestDist {
+			best, bestDist = name, dist
+		}
+	}
+	return best, bestDist
+}
+
+// NearestN returns up to n dictionary colors closest to c by DeltaEOK,
+// closest first, using the dictionary's OKLab index.
+//
+// Example:
+//   for _, m := range color.X11Names.NearestN(picked, 3) {
+//       fmt.Printf("%s (%.3f)\n", m.Name, m.Distance)
+//   }
+func (d *Dictionary) NearestN(c Color, n int) []NameMatch {
+	d.indexOnce.Do(func() {
+		d.index = newOKIndex(d.colors, d.names)
+	})
+	lab := ToOKLAB(c)
+	matches := d.index.nearest([3]float64{lab.L, lab.A, lab.B}, n)
+	for i := range matches {
+		c := d.colors[matches[i].Name]
+		matches[i].Color = c.WithAlpha(c.Alpha())
+	}
+	return matches
+}
+
+// NameOf returns the CSS name of c, if c is exactly a CSS named color
+// at 8-bit precision.
+//
+// Example:
+//   name, ok := color.NameOf(color.RGB(1, 0, 0))  // "red", true
+func NameOf(c Color) (string, bool) {
+	return CSSNames.NameOf(c)
+}
+
+// NearestName returns the CSS named color closest to c and its distance under
+// metric, typically DeltaE2000 or DeltaEOK.
+//
+// Example:
+//   name, dist := color.NearestName(color.RGB(0.9, 0.1, 0.1), color.DeltaE2000)  // "red", ~5.6
+func NearestName(c Color, metric DeltaEFunc) (string, float64) {
+	return CSSNames.Nearest(c, metric)
+}
+
+// byteKey packs a color's 8-bit RGBA values, as used for exact name matching.
+func byteKey(c Color) uint32 {
+	r, g, b, a := c.RGBA()
+	r, g, b, a = resolveNone(r), resolveNone(g), resolveNone(b), resolveNone(a)
+	return uint32(roundByte(r))<<24 | uint32(roundByte(g))<<16 | uint32(roundByte(b))<<8 | uint32(roundByte(a))
+}
+
+// normalizeColorName lowercases a name and collapses whitespace.
+func normalizeColorName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
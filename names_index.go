@@ -0,0 +1,120 @@
+package color
+
+import (
+	"math"
+	"sort"
+)
+
+// okIndex is a k-d tree over the colors of a Dictionary in OKLab, so that
+// nearest-name queries under DeltaEOK do not scan every entry.
+type okIndex struct {
+	points []okPoint
+}
+
+// okPoint is one dictionary entry; points are stored in k-d tree order:
+// the median of each subrange is its node, split on axis depth%3.
+type okPoint struct {
+	lab  [3]float64
+	name string
+}
+
+// newOKIndex builds the index. Fully transparent colors are left out.
+func newOKIndex(colors map[string]Color, names []string) *okIndex {
+	idx := &okIndex{points: make([]okPoint, 0, len(names))}
+	for _, name := range names {
+		c := colors[name]
+		if c.Alpha() == 0 {
+			continue
+		}
+		lab := ToOKLAB(c)
+		idx.points = append(idx.points, okPoint{lab: [3]float64{lab.L, lab.A, lab.B}, name: name})
+	}
+	idx.build(idx.points, 0)
+	return idx
+}
+
+// build arranges pts so that the median on the current axis is in the middle.
+func (idx *okIndex) build(pts []okPoint, depth int) {
+	if len(pts) <= 1 {
+		return
+	}
+	axis := depth % 3
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].lab[axis] != pts[j].lab[axis] {
+			return pts[i].lab[axis] < pts[j].lab[axis]
+		}
+		return pts[i].name < pts[j].name
+	})
+	mid := len(pts) / 2
+	idx.build(pts[:mid], depth+1)
+	idx.build(pts[mid+1:], depth+1)
+}
+
+// nearest returns up to k entries closest to lab, closest first.
+func (idx *okIndex) nearest(lab [3]float64, k int) []NameMatch {
+	if k <= 0 {
+		return nil
+	}
+	s := okSearch{target: lab, k: k}
+	s.search(idx.points, 0)
+
+	matches := make([]NameMatch, len(s.best))
+	for i, b := range s.best {
+		matches[i] = NameMatch{Name: b.name, Distance: math.Sqrt(b.dist2)}
+	}
+	return matches
+}
+
+// okSearch holds the state of one k-nearest query.
+type okSearch struct {
+	target [3]float64
+	k      int
+	best   []okCandidate // sorted by dist2, then name
+}
+
+type okCandidate struct {
+	name  string
+	dist2 float64
+}
+
+func (s *okSearch) search(pts []okPoint, depth int) {
+	if len(pts) == 0 {
+		return
+	}
+	mid := len(pts) / 2
+	p := pts[mid]
+
+	dl := p.lab[0] - s.target[0]
+	da := p.lab[1] - s.target[1]
+	db := p.lab[2] - s.target[2]
+	s.add(okCandidate{name: p.name, dist2: dl*dl + da*da + db*db})
+
+	axis := depth % 3
+	diff := s.target[axis] - p.lab[axis]
+	near, far := pts[:mid], pts[mid+1:]
+	if diff > 0 {
+		near, far = far, near
+	}
+	s.search(near, depth+1)
+	// The other side can only hold closer points if the splitting plane is
+	// within the current k-th best distance
+	if len(s.best) < s.k || diff*diff <= s.best[len(s.best)-1].dist2 {
+		s.search(far, depth+1)
+	}
+}
+
+// add inserts a candidate if it is among the k best so far.
+func (s *okSearch) add(c okCandidate) {
+	i := sort.Search(len(s.best), func(i int) bool {
+		b := s.best[i]
+		return b.dist2 > c.dist2 || (b.dist2 == c.dist2 && b.name > c.name)
+	})
+	if i >= s.k {
+		return
+	}
+	if len(s.best) < s.k {
+		s.best = append(s.best, okCandidate{})
+	}
+	copy(s.best[i+1:], s.best[i:])
+	s.best[i] = c
+}
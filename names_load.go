@@ -0,0 +1,104 @@
+package color
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/x11-rgb.txt
+var x11RGB string
+
+// X11Names holds the 753 X11 color names from rgb.txt, including the
+// numbered variants ("red1" to "red4", "gray0" to "gray100") and both the
+// spaced and camel-case spellings ("ghost white", "GhostWhite").
+//
+// X11 and CSS disagree on a few names; "gray", "green", "maroon" and
+// "purple" are different colors in X11. List CSSNames first to prefer CSS:
+//   opts := color.ParseOptions{Names: []*color.Dictionary{color.CSSNames, color.X11Names}}
+var X11Names = mustParseDictionary("x11", x11RGB)
+
+// ParseDictionary reads a color name list, one color per line, in either
+// of the two common plain-text formats:
+//   255 250 250		snow            (X11 rgb.txt: decimal R G B, then the name)
+//   cloudy blue	#acc2d9         (XKCD rgb.txt: the name, then hex)
+//   #acc2d9 cloudy blue             (hex, then the name)
+// Blank lines and lines starting with "!" or "#" that are not colors are
+// skipped. This reads the XKCD color survey file
+// (https://xkcd.com/color/rgb.txt) and similar published lists.
+func ParseDictionary(name string, r io.Reader) (*Dictionary, error) {
+	colors := make(map[string]Color)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		colorName, c, ok := parseDictionaryLine(text)
+		if !ok {
+			if text[0] == '!' || text[0] == '#' {
+				continue
+			}
+			return nil, fmt.Errorf("color: %s line %d: cannot parse %q", name, line, text)
+		}
+		if _, dup := colors[colorName]; !dup {
+			colors[colorName] = c
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewDictionary(name, colors), nil
+}
+
+// parseDictionaryLine parses one line of ParseDictionary.
+func parseDictionaryLine(text string) (string, Color, bool) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return "", nil, false
+	}
+
+	// X11: R G B name
+	if len(fields) >= 4 {
+		var rgb [3]float64
+		ok := true
+		for i := range rgb {
+			v, err := strconv.Atoi(fields[i])
+			if err != nil || v < 0 || v > 255 {
+				ok = false
+				break
+			}
+			rgb[i] = float64(v) / 255
+		}
+		if ok {
+			return strings.Join(fields[3:], " "), RGB(rgb[0], rgb[1], rgb[2]), true
+		}
+	}
+
+	// name #hex
+	if last := fields[len(fields)-1]; strings.HasPrefix(last, "#") {
+		if c, err := HexToRGB(last); err == nil {
+			return strings.Join(fields[:len(fields)-1], " "), c, true
+		}
+	}
+
+	// #hex name
+	if strings.HasPrefix(fields[0], "#") {
+		if c, err := HexToRGB(fields[0]); err == nil {
+			return strings.Join(fields[1:], " "), c, true
+		}
+	}
+	return "", nil, false
+}
+
+// mustParseDictionary parses an embedded name list.
+func mustParseDictionary(name, data string) *Dictionary {
+	d, err := ParseDictionary(name, strings.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
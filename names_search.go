@@ -0,0 +1,111 @@
+package color
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSearchDistance is the largest text dissimilarity Search returns.
+const maxSearchDistance = 0.75
+
+// Search finds names that approximately match query, best match first, and
+// returns at most limit of them (all matches if limit <= 0).
+//
+// Distance in the results is a text dissimilarity from 0 to 1:
+//   - 0 for an exact match, ignoring case and spaces ("dark red" = "DarkRed")
+//   - below 0.5 when the name contains the query ("rose" in "misty rose")
+//   - up to 0.75 for names whose words are close by edit distance
+//     ("dusty rose" finds "misty rose")
+//
+// Example:
+//   for _, m := range color.X11Names.Search("dusty rose", 5) {
+//       fmt.Println(m.Name, color.RGBToHex(m.Color))
+//   }
+func (d *Dictionary) Search(query string, limit int) []NameMatch {
+	q := normalizeColorName(query)
+	if q == "" {
+		return nil
+	}
+
+	var matches []NameMatch
+	for _, name := range d.names {
+		dist := nameDistance(q, name)
+		if dist > maxSearchDistance {
+			continue
+		}
+		c := d.colors[name]
+		matches = append(matches, NameMatch{Name: name, Color: c.WithAlpha(c.Alpha()), Distance: dist})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// nameDistance scores how well a normalized name matches a normalized query.
+func nameDistance(query, name string) float64 {
+	q := strings.ReplaceAll(query, " ", "")
+	n := strings.ReplaceAll(name, " ", "")
+	if q == n {
+		return 0
+	}
+	if strings.Contains(n, q) {
+		// Shorter names that contain the query rank higher
+		return 0.1 + 0.4*(1-float64(len(q))/float64(len(n)))
+	}
+
+	// Compare word by word, so word order and extra words matter less,
+	// and as a whole, for names written without spaces ("dustyrose")
+	words := strings.Fields(name)
+	total := 0.0
+	for _, qw := range strings.Fields(query) {
+		best := editRatio(qw, n)
+		for _, w := range words {
+			if r := editRatio(qw, w); r < best {
+				best = r
+			}
+		}
+		total += best
+	}
+	wordScore := total / float64(len(strings.Fields(query)))
+
+	score := editRatio(q, n)
+	if wordScore < score {
+		score = wordScore
+	}
+	return 0.5 + 0.5*score
+}
+
+// editRatio is the Levenshtein distance between a and b divided by the
+// length of the longer string.
+func editRatio(a, b string) float64 {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return 0
+	}
+
+	// Single-row dynamic programming over bytes; names are ASCII
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], cur
+		}
+	}
+	return float64(row[len(b)]) / float64(len(a))
+}
@@ -0,0 +1,192 @@
+package color
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNameOf(t *testing.T) {
+	tests := []struct {
+		c    Color
+		want string
+		ok   bool
+	}{
+		{RGB(1, 0, 0), "red", true},
+		{NewRGBA(0, 0, 0, 0), "transparent", true},
+		{RGB(0, 0, 0), "black", true},
+		{RGB(0, 0.5, 0), "green", true},
+		{NewHSL(0, 1, 0.5, 1), "red", true},
+		{RGB(0.5, 0.5, 0.5), "gray", true}, // "gray" sorts before "grey"
+		{RGB(0, 1, 1), "aqua", true},       // "aqua" sorts before "cyan"
+		{NewRGBA(1, 0, 0, 0.5), "", false},
+		{RGB(0.9, 0.1, 0.1), "", false},
+		{NewOKLCH(0.9, 0.37, 145, 1), "", false}, // outside sRGB
+	}
+	for _, tt := range tests {
+		got, ok := NameOf(tt.c)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NameOf(%v) = %q, %v, want %q, %v", tt.c, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// The hex form of a named color matches it
+	c, _ := ParseColor("#ffa500")
+	if name, _ := NameOf(c); name != "orange" {
+		t.Errorf("NameOf(#ffa500) = %q, want orange", name)
+	}
+}
+
+func TestNearestName(t *testing.T) {
+	for _, metric := range []DeltaEFunc{DeltaE2000, DeltaEOK} {
+		name, dist := NearestName(RGB(0.9, 0.1, 0.1), metric)
+		if name != "red" || dist <= 0 {
+			t.Errorf("NearestName(dark red) = %q, %g, want red", name, dist)
+		}
+
+		name, dist = NearestName(RGB(0, 0, 0.5), metric)
+		if name != "navy" || dist > 1e-9 {
+			t.Errorf("NearestName(navy) = %q, %g, want navy, 0", name, dist)
+		}
+
+		// Transparent is never the nearest match
+		if name, _ = NearestName(NewRGBA(0.01, 0, 0, 0), metric); name != "black" {
+			t.Errorf("NearestName(near black) = %q, want black", name)
+		}
+	}
+
+	empty := NewDictionary("empty", nil)
+	if name, dist := empty.Nearest(RGB(1, 0, 0), DeltaEOK); name != "" || !math.IsInf(dist, 1) {
+		t.Errorf("empty.Nearest() = %q, %g", name, dist)
+	}
+}
+
+func TestX11Names(t *testing.T) {
+	if n := X11Names.Len(); n < 700 {
+		t.Errorf("X11Names.Len() = %d, want the full rgb.txt", n)
+	}
+	tests := map[string]string{
+		"ghost white": "#f8f8ff",
+		"GhostWhite":  "#f8f8ff",
+		"red3":        "#cd0000",
+		"gray50":      "#7f7f7f",
+		"gray":        "#bebebe", // X11 gray, not CSS gray
+	}
+	for name, want := range tests {
+		c, ok := X11Names.Lookup(name)
+		if !ok {
+			t.Errorf("X11Names.Lookup(%q) not found", name)
+			continue
+		}
+		if got := RGBToHex(c); got != want {
+			t.Errorf("X11Names.Lookup(%q) = %s, want %s", name, got, want)
+		}
+	}
+
+	// Selectable in ParseOptions, in order
+	c, err := ParseColorWithOptions("gray", ParseOptions{Names: []*Dictionary{CSSNames, X11Names}})
+	if err != nil || RGBToHex(c) != "#7f7f7f" {
+		t.Errorf("CSS first: gray = %v, %v", c, err)
+	}
+	c, err = ParseColorWithOptions("papaya whip", ParseOptions{Names: []*Dictionary{CSSNames, X11Names}})
+	if err != nil || RGBToHex(c) != "#ffefd5" {
+		t.Errorf("papaya whip = %v, %v", c, err)
+	}
+}
+
+func TestParseDictionary(t *testing.T) {
+	input := `#License: http://creativecommons.org/publicdomain/zero/1.0/
+cloudy blue	#acc2d9
+dark pastel green	#56ae57
+
+! X11 style
+255 250 250		snow
+#ff0000 signal red
+`
+	d, err := ParseDictionary("test", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"cloudy blue", "dark pastel green", "signal red", "snow"}
+	if got := d.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	if c, _ := d.Lookup("Cloudy  Blue"); RGBToHex(c) != "#acc2d9" {
+		t.Errorf("cloudy blue = %v", c)
+	}
+
+	_, err = ParseDictionary("bad", strings.NewReader("cloudy blue\n"))
+	if err == nil {
+		t.Error("ParseDictionary accepted a line without a color")
+	}
+}
+
+func TestNearestN(t *testing.T) {
+	target := RGB(0.8, 0.3, 0.35)
+
+	// The index agrees with a linear scan by DeltaEOK
+	name, dist := X11Names.Nearest(target, DeltaEOK)
+	indexed, indexedDist := X11Names.Nearest(target, nil)
+	if RGBToHex(mustLookup(t, X11Names, indexed)) != RGBToHex(mustLookup(t, X11Names, name)) || math.Abs(dist-indexedDist) > 1e-12 {
+		t.Errorf("indexed nearest = %q (%g), scan = %q (%g)", indexed, indexedDist, name, dist)
+	}
+
+	matches := X11Names.NearestN(target, 5)
+	if len(matches) != 5 {
+		t.Fatalf("NearestN returned %d matches", len(matches))
+	}
+	for i, m := range matches {
+		if d := DeltaEOK(target, m.Color); math.Abs(d-m.Distance) > 1e-12 {
+			t.Errorf("match %q: Distance = %g, DeltaEOK = %g", m.Name, m.Distance, d)
+		}
+		if i > 0 && m.Distance < matches[i-1].Distance {
+			t.Errorf("matches not sorted: %v", matches)
+		}
+	}
+
+	// Exhaustive check against every entry
+	for _, n := range X11Names.Names() {
+		c := mustLookup(t, X11Names, n)
+		if DeltaEOK(target, c) < matches[4].Distance-1e-12 {
+			found := false
+			for _, m := range matches {
+				found = found || m.Name == n
+			}
+			if !found {
+				t.Errorf("%q is closer than the 5th match but was not returned", n)
+			}
+		}
+	}
+}
+
+func mustLookup(t *testing.T, d *Dictionary, name string) Color {
+	t.Helper()
+	c, ok := d.Lookup(name)
+	if !ok {
+		t.Fatalf("%s: %q not found", d.Name(), name)
+	}
+	return c
+}
+
+func TestSearch(t *testing.T) {
+	matches := X11Names.Search("dusty rose", 5)
+	if len(matches) == 0 || matches[0].Name != "misty rose" {
+		t.Errorf("Search(dusty rose) = %v, want misty rose first", matches)
+	}
+
+	matches = X11Names.Search("DarkRed", 1)
+	if len(matches) != 1 || matches[0].Distance != 0 {
+		t.Errorf("Search(DarkRed) = %v, want an exact match", matches)
+	}
+
+	for _, m := range X11Names.Search("slate", 0) {
+		if !strings.Contains(m.Name, "slate") && m.Distance < 0.5 {
+			t.Errorf("%q ranked as containing slate", m.Name)
+		}
+	}
+
+	if matches := CSSNames.Search("xyzzy", 0); len(matches) != 0 {
+		t.Errorf("Search(xyzzy) = %v, want no matches", matches)
+	}
+}
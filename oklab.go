@@ -20,31 +20,40 @@ func NewOKLAB(l, a, b, alpha float64) *OKLAB {
 
 // RGBA converts OKLAB to RGBA via linear RGB.
 func (c *OKLAB) RGBA() (r, g, b, a float64) {
-	// Convert OKLAB to linear RGB
-	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
-	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
-	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
-
-	l3 := l * l * l
-	m3 := m * m * m
-	s3 := s * s * s
-
-	linearR := +4.0767416621*l3 - 3.3077115913*m3 + 0.2309699292*s3
-	linearG := -1.2684380046*l3 + 2.6097574011*m3 - 0.3413193965*s3
-	linearB := -0.0041960863*l3 - 0.7034186147*m3 + 1.7076147010*s3
+	linearR, linearG, linearB, a := c.linearRGBA()
 
 	// Apply gamma correction to convert linear RGB to sRGB
 	r = gammaCorrection(linearR)
 	g = gammaCorrection(linearG)
 	b = gammaCorrection(linearB)
-	a = c.A_
 
 	return clamp01(r), clamp01(g), clamp01(b), clamp01(a)
 }
 
+// linearRGBA implements linearColor.
+func (c *OKLAB) linearRGBA() (r, g, b, a float64) {
+	L, A, B := resolveNone(c.L), resolveNone(c.A), resolveNone(c.B)
+
+	// Convert OKLAB to LMS
+	l := L + 0.3963377774*A + 0.2158037573*B
+	m := L - 0.1055613458*A - 0.0638541728*B
+	s := L - 0.0894841775*A - 1.2914855480*B
+
+	l3 := l * l * l
+	m3 := m * m * m
+	s3 := s * s * s
+
+	// Convert LMS to linear RGB
+	r = +4.0767416621*l3 - 3.3077115913*m3 + 0.2309699292*s3
+	g = -1.2684380046*l3 + 2.6097574011*m3 - 0.3413193965*s3
+	b = -0.0041960863*l3 - 0.7034186147*m3 + 1.7076147010*s3
+
+	return r, g, b, c.Alpha()
+}
+
 // Alpha implements Color.
 func (c *OKLAB) Alpha() float64 {
-	return c.A_
+	return resolveNone(c.A_)
 }
 
 // WithAlpha implements Color.
@@ -52,14 +61,23 @@ func (c *OKLAB) WithAlpha(alpha float64) Color {
 	return &OKLAB{L: c.L, A: c.A, B: c.B, A_: clamp01(alpha)}
 }
 
-// ToOKLAB converts an RGBA color to OKLAB.
+// String returns a CSS oklab() representation.
+func (c *OKLAB) String() string {
+	return CSSString(c)
+}
+
+// ToOKLAB converts a color to OKLAB.
+// The conversion is unclamped, so colors outside the sRGB gamut keep their
+// full chroma.
 func ToOKLAB(c Color) *OKLAB {
-	r, g, b, _ := c.RGBA()
+	switch v := c.(type) {
+	case *OKLAB:
+		return &OKLAB{L: resolveNone(v.L), A: resolveNone(v.A), B: resolveNone(v.B), A_: v.Alpha()}
+	case *OKLCH:
+		return v.toOKLAB()
+	}
 
-	// Convert sRGB to linear RGB
-	linearR := inverseGammaCorrection(r)
-	linearG := inverseGammaCorrection(g)
-	linearB := inverseGammaCorrection(b)
+	linearR, linearG, linearB, a := LinearRGBA(c)
 
 	// Convert linear RGB to OKLAB
 	l := 0.4122214708*linearR + 0.5363325363*linearG + 0.0514459929*linearB
@@ -74,7 +92,5 @@ func ToOKLAB(c Color) *OKLAB {
 	oka := 1.9779984951*l3 - 2.4285922050*m3 + 0.4505937099*s3
 	okb := 0.0259040371*l3 + 0.7827717662*m3 - 0.8086757660*s3
 
-	_, _, _, a := c.RGBA()
 	return &OKLAB{L: okl, A: oka, B: okb, A_: a}
 }
-
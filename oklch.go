@@ -24,9 +24,14 @@ func (c *OKLCH) RGBA() (r, g, b, a float64) {
 	return oklab.RGBA()
 }
 
+// linearRGBA implements linearColor.
+func (c *OKLCH) linearRGBA() (r, g, b, a float64) {
+	return c.toOKLAB().linearRGBA()
+}
+
 // Alpha implements Color.
 func (c *OKLCH) Alpha() float64 {
-	return c.A_
+	return resolveNone(c.A_)
 }
 
 // WithAlpha implements Color.
@@ -36,22 +41,22 @@ func (c *OKLCH) WithAlpha(alpha float64) Color {
 
 // String returns a CSS oklch() representation.
 func (c *OKLCH) String() string {
-	if c.A_ < 1.0 {
-		return formatString("oklch(%g %g %g / %g)", c.L, c.C, c.H, c.A_)
-	}
-	return formatString("oklch(%g %g %g)", c.L, c.C, c.H)
+	return CSSString(c)
 }
 
 // toOKLAB converts OKLCH to OKLAB.
 func (c *OKLCH) toOKLAB() *OKLAB {
-	rad := c.H * math.Pi / 180
-	a := c.C * math.Cos(rad)
-	b := c.C * math.Sin(rad)
-	return &OKLAB{L: c.L, A: a, B: b, A_: c.A_}
+	rad := resolveNone(c.H) * math.Pi / 180
+	a := resolveNone(c.C) * math.Cos(rad)
+	b := resolveNone(c.C) * math.Sin(rad)
+	return &OKLAB{L: resolveNone(c.L), A: a, B: b, A_: c.Alpha()}
 }
 
 // ToOKLCH converts an RGBA color to OKLCH.
 func ToOKLCH(c Color) *OKLCH {
+	if v, ok := c.(*OKLCH); ok {
+		return &OKLCH{L: resolveNone(v.L), C: resolveNone(v.C), H: resolveNone(v.H), A_: v.Alpha()}
+	}
 	oklab := ToOKLAB(c)
 	return oklab.toOKLCH()
 }
@@ -2,9 +2,12 @@ package color
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ParseColor parses a color string in various formats and returns a Color.
@@ -22,7 +25,8 @@ import (
 //   - OKLAB: "oklab(0.6 0.1 -0.1)" (perceptually uniform)
 //   - LCH: "lch(70% 50 180)" or "lch(70 50 180)" (CIE LCH from LAB)
 //   - OKLCH: "oklch(0.7 0.2 120)" (perceptually uniform)
-//   - XYZ: "color(xyz 0.5 0.5 0.5)" or "color(xyz-d65 0.5 0.5 0.5)" (CIE 1931 XYZ)
+//   - XYZ: "color(xyz 0.5 0.5 0.5)" or "color(xyz-d65 0.5 0.5 0.5)" (CIE 1931 XYZ);
+//     "color(xyz-d50 ...)" is adapted to D65
 //   - Wide-gamut RGB via color() function:
 //   - "color(srgb 1 0 0)" (sRGB, same as rgb())
 //   - "color(srgb-linear 1 0 0)" (linear sRGB, no gamma)
@@ -30,44 +34,89 @@ import (
 //   - "color(a98-rgb 1 0 0)" (Adobe RGB 1998)
 //   - "color(prophoto-rgb 1 0 0)" (ProPhoto RGB)
 //   - "color(rec2020 1 0 0)" (Rec. 2020, UHDTV)
+//     color() gives an *RGBA for sRGB values within 0-1 and a SpaceColor otherwise,
+//     so wide-gamut and out-of-range values are kept. Outside ParseStrict, channels
+//     written as integers with one above 1 are read as 0-255: "color(srgb 255 128 0)".
 //   - Named colors: "red", "blue", "transparent", etc.
+//   - Relative colors (CSS Color 5): "oklch(from #3366ff calc(l + 0.1) c h)",
+//     "rgb(from red r g b / 50%)", with calc(), min(), max() and clamp()
+//   - Math functions in place of any number: "rgb(calc(255 / 2) 0 0)"
+//   - Color mixing (CSS Color 5): "color-mix(in oklch longer hue, red 30%, blue)"
+//
+// Hues accept the angle units deg, rad, grad and turn. Any component may be the
+// keyword none, which marks it as missing: it is stored as NaN, behaves as zero
+// when displayed, and takes the other color's value when mixed (see HasMissing).
+// Percentages for lab/lch/oklab/oklch channels follow CSS Color 4 (e.g. 100% chroma
+// is 0.4 in oklch and 150 in lch).
 //
 // CIE (Commission Internationale de l'Éclairage) color spaces are fully supported:
 //   - XYZ: CIE 1931 XYZ color space (via color() function)
 //   - LAB: CIE 1976 L*a*b* color space
 //   - LCH: Polar representation of CIE LAB
+//
+// Errors are *ParseError values carrying the offending token, its byte offset
+// and a sentinel such as ErrOutOfRange that errors.Is recognizes.
+//
+// ParseColor uses the default ParseOptions; see ParseColorWithOptions for
+// strict CSS parsing, lenient parsing and other name dictionaries.
 func ParseColor(s string) (Color, error) {
-	s = strings.TrimSpace(s)
+	return ParseColorWithOptions(s, ParseOptions{})
+}
+
+// parseColor implements ParseColorWithOptions. The Offset of a *ParseError
+// is relative to s.
+func parseColor(s string, opts ParseOptions) (Color, error) {
+	trimmed := strings.TrimSpace(s)
+	c, err := parseTrimmedColor(trimmed, opts)
+	if e, ok := err.(*ParseError); ok {
+		e.locate(trimmed)
+		e.shift(strings.Index(s, trimmed))
+	}
+	return c, err
+}
+
+// parseTrimmedColor parses a color string without surrounding whitespace.
+func parseTrimmedColor(s string, opts ParseOptions) (Color, error) {
 	if s == "" {
-		return nil, &ParseError{input: s, reason: "empty string"}
+		return nil, newParseError(ErrEmpty, s, "empty string")
 	}
 
-	// Try hex first (most common)
-	if strings.HasPrefix(s, "#") || isHexString(s) {
-		return HexToRGB(s)
+	// Try hex first (most common); bare hex digits are not CSS
+	if strings.HasPrefix(s, "#") || (opts.Mode != ParseStrict && isHexString(s)) {
+		return parseHex(s, opts)
+	}
+
+	if opts.Mode == ParseLenient {
+		if c, ok, err := parseLenient(s); ok {
+			return c, err
+		}
 	}
 
 	// Try named colors
-	if c, ok := parseNamedColor(s); ok {
+	if c, ok := opts.lookupName(s); ok {
 		return c, nil
 	}
 
 	// Try function formats (rgb, rgba, hsl, etc.)
 	if strings.Contains(s, "(") {
-		return parseFunctionColor(s)
+		return parseFunctionColor(s, opts)
 	}
 
-	return nil, &ParseError{input: s, reason: "unknown format"}
-}
-
-// ParseError represents an error parsing a color string.
-type ParseError struct {
-	input  string
-	reason string
+	if isIdentifier(s) {
+		return nil, newParseError(ErrUnknownName, s, fmt.Sprintf("unknown color name %q", s))
+	}
+	return nil, newParseError(ErrSyntax, s, "unknown format")
 }
 
-func (e *ParseError) Error() string {
-	return fmt.Sprintf("cannot parse color %q: %s", e.input, e.reason)
+// isIdentifier reports whether s looks like a CSS identifier such as a color name.
+func isIdentifier(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // ASCII lowercase
+		if !(c >= 'a' && c <= 'z') && !(i > 0 && (isDigit(s[i]) || s[i] == '-')) {
+			return false
+		}
+	}
+	return s != ""
 }
 
 // isHexString checks if a string looks like a hex color (without #).
@@ -85,104 +134,151 @@ func isHexString(s string) bool {
 }
 
 // parseFunctionColor parses CSS function-style color strings.
-func parseFunctionColor(s string) (Color, error) {
+func parseFunctionColor(s string, opts ParseOptions) (Color, error) {
 	s = strings.TrimSpace(s)
 	s = strings.ToLower(s)
 
 	// Extract function name and arguments
 	// Handle both simple functions (rgb(...)) and color() function (color(xyz ...))
-	re := regexp.MustCompile(`^(\w+)(?:-[\w]+)?\(([^)]+)\)$`)
+	// color-mix() has its own comma-separated grammar
+	if strings.HasPrefix(s, "color-mix(") {
+		c, err := parseColorMix(s, opts)
+		if e, ok := err.(*ParseError); ok {
+			e.setContext("color-mix", nil, nil)
+		}
+		return c, err
+	}
+
+	// Arguments may contain nested functions (calc(), origin colors in relative syntax)
+	re := regexp.MustCompile(`^([\w-]+)\((.+)\)$`)
 	matches := re.FindStringSubmatch(s)
-	if len(matches) != 3 {
-		return nil, &ParseError{input: s, reason: "invalid function format"}
+	if len(matches) != 3 || matchingParen(s, strings.Index(s, "(")) != len(s)-1 {
+		return nil, newParseError(ErrSyntax, s, "invalid function format")
 	}
 
 	funcName := strings.ToLower(matches[1])
 	args := matches[2]
 
 	// Parse arguments (split by comma, handle spaces)
-	argList := parseArgs(args)
-
-	// Handle color() function specially - it has format color(xyz 0.5 0.5 0.5)
-	// The color space name is the first argument
-	if funcName == "color" {
-		return parseColorFunction(argList)
-	}
-
-	switch funcName {
-	case "rgb":
-		return parseRGB(argList, false)
-	case "rgba":
-		return parseRGB(argList, true)
-	case "hsl":
-		return parseHSL(argList, false)
-	case "hsla":
-		return parseHSL(argList, true)
-	case "hwb":
-		return parseHWB(argList)
-	case "hsv":
-		return parseHSV(argList, false)
-	case "hsva":
-		return parseHSV(argList, true)
-	case "lab":
-		return parseLAB(argList)
-	case "oklab":
-		return parseOKLAB(argList)
-	case "lch":
-		return parseLCH(argList)
-	case "oklch":
-		return parseOKLCH(argList)
-	case "color":
-		return parseColorFunction(argList)
-	case "xyz":
-		return parseXYZ(argList)
-	default:
-		return nil, &ParseError{input: s, reason: fmt.Sprintf("unknown function: %s", funcName)}
-	}
-}
-
-// parseArgs splits function arguments, handling commas, spaces, and slashes.
+	argList, offsets := parseArgs(args)
+
+	c, err := parseFunction(funcName, argList, opts)
+	if e, ok := err.(*ParseError); ok {
+		// Position the arguments in s, after "name("
+		for i := range offsets {
+			offsets[i] += len(matches[1]) + 1
+		}
+		e.setContext(funcName, argList, offsets)
+	}
+	return c, err
+}
+
+// parseFunction parses the split arguments of a color function.
+func parseFunction(funcName string, argList []string, opts ParseOptions) (Color, error) {
+	if opts.Mode == ParseStrict && !cssFunctions[funcName] {
+		return nil, newParseError(ErrUnknownFunction, funcName, fmt.Sprintf("%s() is not a CSS color function", funcName))
+	}
+
+	// Relative color syntax: oklch(from <color> l c h)
+	if len(argList) > 0 && argList[0] == "from" {
+		return parseRelativeColor(funcName, argList, opts)
+	}
+
+	// Built-in and custom functions (see RegisterFunction)
+	parser, ok := GetFunctionParser(funcName)
+	if opts.Mode == ParseStrict {
+		parser, ok = builtinFunctions[funcName]
+	}
+	if !ok {
+		return nil, newParseError(ErrUnknownFunction, funcName, fmt.Sprintf("unknown function: %s", funcName))
+	}
+	return parser(argList, opts)
+}
+
+// parseArgs splits function arguments, handling commas, spaces, and slashes,
+// and returns the byte offset of each argument in s.
 // Modern CSS syntax uses spaces and "/" for alpha: "rgb(255 0 0 / 0.5)"
 // For LAB/OKLAB/LCH/OKLCH, spaces are used. For others, commas or spaces.
-func parseArgs(s string) []string {
+// Separators inside nested parentheses, as in "calc(l / 2)", are not split.
+func parseArgs(s string) (args []string, offsets []int) {
 	// Remove extra whitespace
-	s = strings.TrimSpace(s)
+	s, base := trimAt(s, 0)
 
 	// Handle alpha with slash (modern syntax): "255 0 0 / 0.5"
-	if strings.Contains(s, "/") {
-		parts := strings.Split(s, "/")
-		if len(parts) == 2 {
-			// Split main args and alpha
-			mainArgs := parseArgs(parts[0])
-			alpha := strings.TrimSpace(parts[1])
-			return append(mainArgs, alpha)
-		}
-	}
-
-	// Check if it contains commas (legacy RGB, HSL, HSV use commas)
-	if strings.Contains(s, ",") {
-		// Split by comma, then trim each part
-		parts := strings.Split(s, ",")
-		result := make([]string, 0, len(parts))
-		for _, part := range parts {
-			part = strings.TrimSpace(part)
-			if part != "" {
-				result = append(result, part)
-			}
+	parts, starts := splitTopLevelAt(s, func(c rune) bool { return c == '/' })
+	if len(parts) == 2 {
+		// Split main args and alpha
+		args, offsets = parseArgs(parts[0])
+		for i := range offsets {
+			offsets[i] += base
 		}
-		return result
+		alpha, at := trimAt(parts[1], base+starts[1])
+		return append(args, alpha), append(offsets, at)
 	}
 
-	// For space-separated (LAB, OKLAB, LCH, OKLCH, modern RGB/HSL), split by whitespace
-	parts := strings.Fields(s)
-	result := make([]string, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
+	// Check if it contains commas (legacy RGB, HSL, HSV use commas);
+	// otherwise split by whitespace (LAB, OKLAB, LCH, OKLCH, modern RGB/HSL)
+	parts, starts = splitTopLevelAt(s, func(c rune) bool { return c == ',' })
+	if len(parts) == 1 {
+		parts, starts = splitTopLevelAt(s, unicode.IsSpace)
+	}
+	args = make([]string, 0, len(parts))
+	offsets = make([]int, 0, len(parts))
+	for i, part := range parts {
+		part, at := trimAt(part, base+starts[i])
 		if part != "" && part != "/" {
-			result = append(result, part)
+			args = append(args, part)
+			offsets = append(offsets, at)
 		}
 	}
-	return result
+	return args, offsets
+}
+
+// trimAt trims the whitespace around s, which starts at offset, and returns
+// the offset of the trimmed string.
+func trimAt(s string, offset int) (string, int) {
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	return strings.TrimRightFunc(trimmed, unicode.IsSpace), offset + len(s) - len(trimmed)
+}
+
+// splitTopLevel splits s around the runes matching sep that are not inside parentheses.
+func splitTopLevel(s string, sep func(rune) bool) []string {
+	parts, _ := splitTopLevelAt(s, sep)
+	return parts
+}
+
+// splitTopLevelAt is splitTopLevel, also returning the byte offset of each part in s.
+func splitTopLevelAt(s string, sep func(rune) bool) (parts []string, starts []int) {
+	depth, start := 0, 0
+	for i, c := range s {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && sep(c):
+			parts, starts = append(parts, s[start:i]), append(starts, start)
+			start = i + utf8.RuneLen(c)
+		}
+	}
+	return append(parts, s[start:]), append(starts, start)
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open, or -1.
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i >= 0 && i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
 // parseNumber parses a number that can be:
@@ -190,10 +286,15 @@ func parseArgs(s string) []string {
 // - A float: "0.5"
 // - A percentage: "50%" (returns 0.5)
 // - A float with unit: "50deg" (returns 50, unit ignored for now)
+// - A math function: "calc(255 / 2)", "min(50%, 0.3)", "clamp(0, 1.2, 1)"
 func parseNumber(s string) (float64, error) {
 	s = strings.TrimSpace(s)
 	s = strings.ToLower(s)
 
+	if isCalcExpression(s) {
+		return evalCalc(s, calcContext{percentRef: 1})
+	}
+
 	// Remove common units (deg, etc.)
 	s = strings.TrimSuffix(s, "deg")
 	s = strings.TrimSpace(s)
@@ -202,7 +303,7 @@ func parseNumber(s string) (float64, error) {
 	if strings.HasSuffix(s, "%") {
 		val, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
 		if err != nil {
-			return 0, err
+			return 0, newParseError(ErrBadNumber, s, fmt.Sprintf("invalid percentage %q", s))
 		}
 		return val / 100.0, nil
 	}
@@ -210,166 +311,291 @@ func parseNumber(s string) (float64, error) {
 	// Regular number
 	val, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		return 0, err
+		return 0, newParseError(ErrBadNumber, s, fmt.Sprintf("invalid number %q", s))
 	}
 	return val, nil
 }
 
+// ParseChannel parses one argument of a color function, for use in a
+// FunctionParser: a number, a percentage (100% = percentRef), a math function
+// such as "calc(50% + 0.1)", or none, which returns NaN.
+func ParseChannel(s string, percentRef float64) (float64, error) {
+	return parseComponent(s, percentRef)
+}
+
+// ParseAngle parses a hue argument, for use in a FunctionParser, and returns
+// it in degrees. It accepts the units deg, rad, grad and turn, math functions,
+// and none, which returns NaN.
+func ParseAngle(s string) (float64, error) {
+	return parseHue(s)
+}
+
+// parseComponent parses a color channel: a number, a percentage, a math function
+// or the keyword none. Percentages resolve against percentRef (100% = percentRef).
+// none marks a missing component and is returned as NaN.
+func parseComponent(s string, percentRef float64) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" {
+		return math.NaN(), nil
+	}
+
+	v, err := parseNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasSuffix(s, "%") {
+		v *= percentRef
+	}
+	return v, nil
+}
+
+// parseHue parses a hue angle and returns it in degrees.
+// Accepts plain numbers (degrees), the units deg, rad, grad and turn,
+// math functions, and the keyword none (returned as NaN).
+func parseHue(s string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" {
+		return math.NaN(), nil
+	}
+	if isCalcExpression(s) {
+		return evalCalc(s, calcContext{})
+	}
+
+	// Split the number from its unit
+	i := len(s)
+	for i > 0 && isCalcIdentStart(s[i-1]) {
+		i--
+	}
+	v, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, newParseError(ErrBadNumber, s, fmt.Sprintf("invalid hue %q", s))
+	}
+	deg, ok := angleToDegrees(v, s[i:])
+	if !ok {
+		e := newParseError(ErrUnknownUnit, s, fmt.Sprintf("unknown angle unit %q", s[i:]))
+		e.tokenPos = i
+		return 0, e
+	}
+	return deg, nil
+}
+
+// angleToDegrees converts an angle in a CSS unit to degrees.
+// An empty unit means degrees. ok is false for unknown units.
+func angleToDegrees(v float64, unit string) (deg float64, ok bool) {
+	switch unit {
+	case "", "deg":
+		return v, true
+	case "rad":
+		return v * 180 / math.Pi, true
+	case "grad":
+		return v * 0.9, true
+	case "turn":
+		return v * 360, true
+	}
+	return 0, false
+}
+
 // parseRGB parses RGB/RGBA arguments.
 // Supports both legacy (comma-separated) and modern (space-separated) syntax.
-func parseRGB(args []string, hasAlpha bool) (Color, error) {
+// In ParseStrict mode numbers are always 0-255, as in CSS.
+func parseRGB(args []string, hasAlpha bool, opts ParseOptions) (Color, error) {
 	// Check for too many arguments - max 4 (rgb + alpha)
 	if len(args) > 4 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "RGB/RGBA requires at most 4 arguments"}
+		return nil, newParseError(ErrArgCount, "", "RGB/RGBA requires at most 4 arguments")
 	}
 
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "RGB requires at least 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "RGB requires at least 3 arguments")
 	}
 
-	r, err := parseNumber(args[0])
+	var r, g, b float64
+	var err error
+	if opts.Mode == ParseStrict {
+		r, g, b, err = parseCSSChannels(args, 0, 255)
+	} else {
+		r, g, b, err = parseRGBChannels(args)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	var a float64 = 1.0
+	// Check for alpha (either explicit hasAlpha flag or 4th argument)
+	if hasAlpha || len(args) >= 4 {
+		if len(args) < 4 {
+			return nil, newParseError(ErrArgCount, "", "RGBA requires 4 arguments")
+		}
+		a, err = parseComponent(args[3], 1)
+		if err != nil {
+			return nil, argError(err, 3)
+		}
+		// Alpha is always 0-1, not 0-255
+	}
+
+	return NewRGBA(r, g, b, a), nil
+}
+
+// parseRGBChannels parses the red, green and blue arguments of rgb(): 0-1 when
+// all are at most 1, otherwise 0-255. Negative and larger values are errors.
+func parseRGBChannels(args []string) (r, g, b float64, err error) {
+	r, err = parseComponent(args[0], 1)
+	if err != nil {
+		return 0, 0, 0, argError(err, 0)
+	}
 	// Validate range before conversion
 	if r < 0 {
-		return nil, &ParseError{input: args[0], reason: "RGB red component cannot be negative"}
+		return 0, 0, 0, newArgError(ErrOutOfRange, args, 0, "RGB red component cannot be negative")
 	}
 	// If > 1, assume 0-255 range, validate and convert to 0-1
 	if r > 1 {
 		if r > 255 {
-			return nil, &ParseError{input: args[0], reason: "RGB red component out of range (0-255)"}
+			return 0, 0, 0, newArgError(ErrOutOfRange, args, 0, "RGB red component out of range (0-255)")
 		}
 		r = r / 255.0
 	}
 
-	g, err := parseNumber(args[1])
+	g, err = parseComponent(args[1], 1)
 	if err != nil {
-		return nil, err
+		return 0, 0, 0, argError(err, 1)
 	}
 	if g < 0 {
-		return nil, &ParseError{input: args[1], reason: "RGB green component cannot be negative"}
+		return 0, 0, 0, newArgError(ErrOutOfRange, args, 1, "RGB green component cannot be negative")
 	}
 	if g > 1 {
 		if g > 255 {
-			return nil, &ParseError{input: args[1], reason: "RGB green component out of range (0-255)"}
+			return 0, 0, 0, newArgError(ErrOutOfRange, args, 1, "RGB green component out of range (0-255)")
 		}
 		g = g / 255.0
 	}
 
-	b, err := parseNumber(args[2])
+	b, err = parseComponent(args[2], 1)
 	if err != nil {
-		return nil, err
+		return 0, 0, 0, argError(err, 2)
 	}
 	if b < 0 {
-		return nil, &ParseError{input: args[2], reason: "RGB blue component cannot be negative"}
+		return 0, 0, 0, newArgError(ErrOutOfRange, args, 2, "RGB blue component cannot be negative")
 	}
 	if b > 1 {
 		if b > 255 {
-			return nil, &ParseError{input: args[2], reason: "RGB blue component out of range (0-255)"}
+			return 0, 0, 0, newArgError(ErrOutOfRange, args, 2, "RGB blue component out of range (0-255)")
 		}
 		b = b / 255.0
 	}
+	return r, g, b, nil
+}
 
-	var a float64 = 1.0
-	// Check for alpha (either explicit hasAlpha flag or 4th argument)
-	if hasAlpha || len(args) >= 4 {
-		if len(args) < 4 {
-			return nil, &ParseError{input: strings.Join(args, ","), reason: "RGBA requires 4 arguments"}
-		}
-		a, err = parseNumber(args[3])
+// parseCSSChannels parses the channels of args[first:] the way CSS computes
+// them: numbers and percentages are relative to ref (100% = ref), and values
+// outside 0-ref are clamped. The results are in 0-1; none stays NaN.
+func parseCSSChannels(args []string, first int, ref float64) (v0, v1, v2 float64, err error) {
+	var v [3]float64
+	for i := first; i < 3; i++ {
+		c, err := parseComponent(args[i], ref)
 		if err != nil {
-			return nil, err
+			return 0, 0, 0, argError(err, i)
 		}
-		// Alpha is always 0-1, not 0-255
+		v[i] = clamp01(c / ref)
 	}
-
-	return NewRGBA(r, g, b, a), nil
+	return v[0], v[1], v[2], nil
 }
 
 // parseHSL parses HSL/HSLA arguments.
 // Supports both legacy (comma-separated) and modern (space-separated) syntax.
-func parseHSL(args []string, hasAlpha bool) (Color, error) {
+// In ParseStrict mode saturation and lightness may be unitless (0-100) and are
+// clamped, as in CSS.
+func parseHSL(args []string, hasAlpha bool, opts ParseOptions) (Color, error) {
 	// Check for too many arguments - max 4 (hsl + alpha)
 	if len(args) > 4 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "HSL/HSLA requires at most 4 arguments"}
+		return nil, newParseError(ErrArgCount, "", "HSL/HSLA requires at most 4 arguments")
 	}
 
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "HSL requires at least 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "HSL requires at least 3 arguments")
 	}
 
-	h, err := parseNumber(args[0])
+	h, err := parseHue(args[0])
 	if err != nil {
-		return nil, err
-	}
-	// Hue is in degrees, validate range 0-360
-	if h < 0 || h > 360 {
-		return nil, &ParseError{input: args[0], reason: "HSL hue out of range (0-360 degrees)"}
+		return nil, argError(err, 0)
 	}
+	// Hue is in degrees; like CSS, any angle is taken modulo 360 by NewHSL
 
-	s, err := parseNumber(args[1])
-	if err != nil {
-		return nil, err
+	var s, l float64
+	if opts.Mode == ParseStrict {
+		_, s, l, err = parseCSSChannels(args, 1, 100)
+	} else {
+		s, l, err = parseHSLChannels(args)
 	}
-	// Saturation is 0-1 (or 0-100% which parseNumber handles)
-	if s < 0 || s > 1 {
-		return nil, &ParseError{input: args[1], reason: "HSL saturation out of range (0-100%)"}
-	}
-
-	l, err := parseNumber(args[2])
 	if err != nil {
 		return nil, err
 	}
-	// Lightness is 0-1 (or 0-100% which parseNumber handles)
-	if l < 0 || l > 1 {
-		return nil, &ParseError{input: args[2], reason: "HSL lightness out of range (0-100%)"}
-	}
 
 	var a float64 = 1.0
 	// Check for alpha (either explicit hasAlpha flag or 4th argument)
 	if hasAlpha || len(args) >= 4 {
 		if len(args) < 4 {
-			return nil, &ParseError{input: strings.Join(args, ","), reason: "HSLA requires 4 arguments"}
+			return nil, newParseError(ErrArgCount, "", "HSLA requires 4 arguments")
 		}
-		a, err = parseNumber(args[3])
+		a, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
 	return NewHSL(h, s, l, a), nil
 }
 
+// parseHSLChannels parses the saturation and lightness arguments of hsl()
+// as 0-1 or percentages. Values outside 0-100% are errors.
+func parseHSLChannels(args []string) (s, l float64, err error) {
+	s, err = parseComponent(args[1], 1)
+	if err != nil {
+		return 0, 0, argError(err, 1)
+	}
+	// Saturation is 0-1 (or 0-100% which parseNumber handles)
+	if s < 0 || s > 1 {
+		return 0, 0, newArgError(ErrOutOfRange, args, 1, "HSL saturation out of range (0-100%)")
+	}
+
+	l, err = parseComponent(args[2], 1)
+	if err != nil {
+		return 0, 0, argError(err, 2)
+	}
+	// Lightness is 0-1 (or 0-100% which parseNumber handles)
+	if l < 0 || l > 1 {
+		return 0, 0, newArgError(ErrOutOfRange, args, 2, "HSL lightness out of range (0-100%)")
+	}
+	return s, l, nil
+}
+
 // parseHSV parses HSV/HSVA arguments.
 func parseHSV(args []string, hasAlpha bool) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "HSV requires at least 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "HSV requires at least 3 arguments")
 	}
 
-	h, err := parseNumber(args[0])
+	h, err := parseHue(args[0])
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
 
-	s, err := parseNumber(args[1])
+	s, err := parseComponent(args[1], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 
-	v, err := parseNumber(args[2])
+	v, err := parseComponent(args[2], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	var a float64 = 1.0
 	if hasAlpha {
 		if len(args) < 4 {
-			return nil, &ParseError{input: strings.Join(args, ","), reason: "HSVA requires 4 arguments"}
+			return nil, newParseError(ErrArgCount, "", "HSVA requires 4 arguments")
 		}
-		a, err = parseNumber(args[3])
+		a, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -377,35 +603,36 @@ func parseHSV(args []string, hasAlpha bool) (Color, error) {
 }
 
 // parseLAB parses LAB arguments.
-func parseLAB(args []string) (Color, error) {
+func parseLAB(args []string, opts ParseOptions) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "LAB requires 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "LAB requires 3 arguments")
 	}
 
-	l, err := parseNumber(args[0])
+	l, err := parseComponent(args[0], 100)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
-	// L can be 0-100 or 0-1 (percentage)
-	if l <= 1 {
+	// L is 0-100 (100% = 100); outside strict mode, unitless values up to 1
+	// are treated as a 0-1 fraction
+	if opts.Mode != ParseStrict && l <= 1 && !strings.HasSuffix(args[0], "%") {
 		l = l * 100 // Convert to 0-100 range
 	}
 
-	a, err := parseNumber(args[1])
+	a, err := parseComponent(args[1], 125)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 
-	b, err := parseNumber(args[2])
+	b, err := parseComponent(args[2], 125)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -415,30 +642,30 @@ func parseLAB(args []string) (Color, error) {
 // parseOKLAB parses OKLAB arguments.
 func parseOKLAB(args []string) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "OKLAB requires 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "OKLAB requires 3 arguments")
 	}
 
-	l, err := parseNumber(args[0])
+	l, err := parseComponent(args[0], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
 	// OKLAB L is 0-1
 
-	a, err := parseNumber(args[1])
+	a, err := parseComponent(args[1], 0.4)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 
-	b, err := parseNumber(args[2])
+	b, err := parseComponent(args[2], 0.4)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -446,35 +673,36 @@ func parseOKLAB(args []string) (Color, error) {
 }
 
 // parseLCH parses LCH arguments.
-func parseLCH(args []string) (Color, error) {
+func parseLCH(args []string, opts ParseOptions) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "LCH requires 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "LCH requires 3 arguments")
 	}
 
-	l, err := parseNumber(args[0])
+	l, err := parseComponent(args[0], 100)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
-	// L can be 0-100 or 0-1 (percentage)
-	if l <= 1 {
+	// L is 0-100 (100% = 100); outside strict mode, unitless values up to 1
+	// are treated as a 0-1 fraction
+	if opts.Mode != ParseStrict && l <= 1 && !strings.HasSuffix(args[0], "%") {
 		l = l * 100 // Convert to 0-100 range
 	}
 
-	c, err := parseNumber(args[1])
+	c, err := parseComponent(args[1], 150)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 
-	h, err := parseNumber(args[2])
+	h, err := parseHue(args[2])
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -484,37 +712,37 @@ func parseLCH(args []string) (Color, error) {
 // parseOKLCH parses OKLCH arguments.
 func parseOKLCH(args []string) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, ","), reason: "OKLCH requires 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "OKLCH requires 3 arguments")
 	}
 
-	l, err := parseNumber(args[0])
+	l, err := parseComponent(args[0], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
 	// OKLCH L is 0-1
 	if l < 0 || l > 1 {
-		return nil, &ParseError{input: args[0], reason: "OKLCH lightness out of range (0-1)"}
+		return nil, newArgError(ErrOutOfRange, args, 0, "OKLCH lightness out of range (0-1)")
 	}
 
-	c, err := parseNumber(args[1])
+	c, err := parseComponent(args[1], 0.4)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 	// Chroma must be non-negative
 	if c < 0 {
-		return nil, &ParseError{input: args[1], reason: "OKLCH chroma cannot be negative"}
+		return nil, newArgError(ErrOutOfRange, args, 1, "OKLCH chroma cannot be negative")
 	}
 
-	h, err := parseNumber(args[2])
+	h, err := parseHue(args[2])
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -522,85 +750,75 @@ func parseOKLCH(args []string) (Color, error) {
 }
 
 // parseHWB parses HWB (Hue, Whiteness, Blackness) arguments.
-func parseHWB(args []string) (Color, error) {
+// In ParseStrict mode whiteness and blackness may be unitless (0-100) and are
+// clamped, as in CSS.
+func parseHWB(args []string, opts ParseOptions) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, " "), reason: "HWB requires at least 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "HWB requires at least 3 arguments")
 	}
 
-	h, err := parseNumber(args[0])
+	h, err := parseHue(args[0])
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
 	// Hue is in degrees
 
-	w, err := parseNumber(args[1])
-	if err != nil {
-		return nil, err
-	}
-	// Whiteness is 0-1 (or 0-100% which parseNumber handles)
+	var w, b float64
+	if opts.Mode == ParseStrict {
+		_, w, b, err = parseCSSChannels(args, 1, 100)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		w, err = parseComponent(args[1], 1)
+		if err != nil {
+			return nil, argError(err, 1)
+		}
+		// Whiteness is 0-1 (or 0-100% which parseNumber handles)
 
-	b, err := parseNumber(args[2])
-	if err != nil {
-		return nil, err
+		b, err = parseComponent(args[2], 1)
+		if err != nil {
+			return nil, argError(err, 2)
+		}
+		// Blackness is 0-1 (or 0-100% which parseNumber handles)
 	}
-	// Blackness is 0-1 (or 0-100% which parseNumber handles)
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
-	// Convert HWB to RGB
-	// HWB is similar to HSV but uses whiteness and blackness
-	// Algorithm: w + b cannot exceed 1, if it does, scale both down
-	sum := w + b
-	if sum > 1 {
-		w = w / sum
-		b = b / sum
-	}
-
-	// Convert to RGB via HSV-like calculation
-	// This is a simplified conversion
-	v := 1 - b
-	s := 0.0
-	if v > 0 {
-		s = 1 - (w / v)
-	}
-
-	// Create HSV and convert to RGB
-	hsv := NewHSV(h, s, v, alpha)
-	return hsv, nil
+	return NewHWB(h, w, b, alpha), nil
 }
 
-// parseXYZ parses XYZ color space arguments.
 func parseXYZ(args []string) (Color, error) {
 	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, " "), reason: "XYZ requires 3 arguments"}
+		return nil, newParseError(ErrArgCount, "", "XYZ requires 3 arguments")
 	}
 
-	x, err := parseNumber(args[0])
+	x, err := parseComponent(args[0], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
 
-	y, err := parseNumber(args[1])
+	y, err := parseComponent(args[1], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 1)
 	}
 
-	z, err := parseNumber(args[2])
+	z, err := parseComponent(args[2], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
@@ -618,85 +836,149 @@ func parseXYZ(args []string) (Color, error) {
 //   - a98-rgb (Adobe RGB 1998)
 //   - prophoto-rgb (ProPhoto RGB)
 //   - rec2020 (Rec. 2020, UHDTV)
-func parseColorFunction(args []string) (Color, error) {
+func parseColorFunction(args []string, opts ParseOptions) (Color, error) {
 	if len(args) < 4 {
-		return nil, &ParseError{input: strings.Join(args, " "), reason: "color() function requires color space name and 3 values"}
+		return nil, newParseError(ErrArgCount, "", "color() function requires color space name and 3 values")
 	}
 
 	colorSpace := strings.ToLower(strings.TrimSpace(args[0]))
+	if opts.Mode == ParseStrict && !cssPredefinedSpaces[colorSpace] {
+		return nil, newArgError(ErrUnknownColorSpace, args, 0, fmt.Sprintf("%s is not a CSS predefined color space", colorSpace))
+	}
 
 	// Handle CIE XYZ color spaces (xyz, xyz-d50, xyz-d65)
-	// Note: Currently all XYZ variants use D65 white point
+	// xyz-d50 is adapted to D65, like every XYZ in this package
+	if colorSpace == "xyz-d50" {
+		c, err := parseXYZ(args[1:])
+		if err != nil {
+			return nil, argsFrom(err, 1)
+		}
+		return xyzFromD50(c.(*XYZ)), nil
+	}
 	if strings.HasPrefix(colorSpace, "xyz") {
 		// XYZ values (x, y, z) - CIE 1931 XYZ color space
-		return parseXYZ(args[1:])
+		c, err := parseXYZ(args[1:])
+		return c, argsFrom(err, 1)
 	}
 
 	// Handle RGB color spaces (srgb, display-p3, a98-rgb, etc.)
 	if rgbSpace := getRGBColorSpace(colorSpace); rgbSpace != nil {
-		return parseRGBColorSpace(args[1:], rgbSpace)
+		c, err := parseRGBColorSpace(args[1:], rgbSpace, opts)
+		return c, argsFrom(err, 1)
 	}
 
-	return nil, &ParseError{input: strings.Join(args, " "), reason: fmt.Sprintf("unsupported color space in color() function: %s (supported: xyz, xyz-d50, xyz-d65, srgb, srgb-linear, display-p3, a98-rgb, prophoto-rgb, rec2020)", colorSpace)}
+	// Any other space registered with RegisterSpace (LOG spaces, custom spaces)
+	if space, ok := GetSpace(colorSpace); ok {
+		c, err := parseSpaceChannels(args[1:], space)
+		return c, argsFrom(err, 1)
+	}
+
+	return nil, newArgError(ErrUnknownColorSpace, args, 0, fmt.Sprintf("unknown color space in color() function: %s (see ListSpaces)", colorSpace))
 }
 
-// parseRGBColorSpace parses RGB values for a specific RGB color space.
-func parseRGBColorSpace(args []string, space *RGBColorSpace) (Color, error) {
-	if len(args) < 3 {
-		return nil, &ParseError{input: strings.Join(args, " "), reason: fmt.Sprintf("%s requires 3 arguments", space.Name)}
+// parseSpaceChannels parses the channels of a registered Space in color(),
+// e.g. "color(s-log3 0.41 0.39 0.35)", into a SpaceColor.
+func parseSpaceChannels(args []string, space Space) (Color, error) {
+	n := space.Channels()
+	if len(args) != n && len(args) != n+1 {
+		return nil, newParseError(ErrArgCount, "", fmt.Sprintf("%s requires %d channel values", space.Name(), n))
 	}
 
-	r, err := parseNumber(args[0])
-	if err != nil {
-		return nil, err
+	values := make([]float64, n)
+	for i := range values {
+		v, err := parseComponent(args[i], 1)
+		if err != nil {
+			return nil, argError(err, i)
+		}
+		values[i] = resolveNone(v)
 	}
-	// If > 1, assume 0-255 range, convert to 0-1
-	if r > 1 {
-		r = r / 255.0
+
+	alpha := 1.0
+	if len(args) > n {
+		v, err := parseComponent(args[n], 1)
+		if err != nil {
+			return nil, argError(err, n)
+		}
+		alpha = resolveNone(v)
+	}
+
+	return NewSpaceColor(space, values, alpha), nil
+}
+
+// xyzFromD50 adapts XYZ read relative to D50 to D65 with the Bradford transform.
+// Adaptation mixes the components, so missing (none) ones resolve to zero first.
+func xyzFromD50(c *XYZ) *XYZ {
+	x, y, z := AdaptD50ToD65(resolveNone(c.X), resolveNone(c.Y), resolveNone(c.Z))
+	return NewXYZ(x, y, z, c.A)
+}
+
+// parseRGBColorSpace parses RGB values for a specific RGB color space.
+func parseRGBColorSpace(args []string, space *RGBColorSpace, opts ParseOptions) (Color, error) {
+	if len(args) < 3 {
+		return nil, newParseError(ErrArgCount, "", fmt.Sprintf("%s requires 3 arguments", space.Name))
 	}
 
-	g, err := parseNumber(args[1])
+	// Channels are 0-1 (or percentages); values outside that range are
+	// kept so colors outside the space's gamut survive a round trip
+	r, err := parseComponent(args[0], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 0)
 	}
-	if g > 1 {
-		g = g / 255.0
+	g, err := parseComponent(args[1], 1)
+	if err != nil {
+		return nil, argError(err, 1)
 	}
-
-	b, err := parseNumber(args[2])
+	b, err := parseComponent(args[2], 1)
 	if err != nil {
-		return nil, err
+		return nil, argError(err, 2)
 	}
-	if b > 1 {
-		b = b / 255.0
+
+	// Outside ParseStrict, channels written as 0-255 integers are scaled to 0-1
+	if opts.Mode != ParseStrict && isByteTriplet(args[:3], r, g, b) {
+		r, g, b = r/255, g/255, b/255
 	}
 
 	alpha := 1.0
 	if len(args) >= 4 {
-		alpha, err = parseNumber(args[3])
+		alpha, err = parseComponent(args[3], 1)
 		if err != nil {
-			return nil, err
+			return nil, argError(err, 3)
 		}
 	}
 
-	// For wide-gamut color spaces, we need to convert to XYZ then to sRGB
-	// Convert the RGB values in the source color space to XYZ
-	xyz := space.ConvertRGBToXYZ(r, g, b, alpha)
+	return newRGBColorSpaceColor(space, r, g, b, alpha), nil
+}
 
-	// Convert XYZ to sRGB for the Color interface
-	// (All colors must be convertible to RGBA via the Color interface)
-	// We return an RGBA color that represents the closest sRGB approximation
-	r2, g2, b2, a2 := xyz.RGBA()
-	return NewRGBA(r2, g2, b2, a2), nil
+// isByteTriplet reports whether color() channels are written in 0-255, as
+// ParseColor has always accepted: all three are plain integers and at least
+// one is above 1. Decimals such as "1.2" are out-of-gamut 0-1 values.
+func isByteTriplet(args []string, r, g, b float64) bool {
+	for _, arg := range args {
+		for _, ch := range strings.TrimSpace(arg) {
+			if ch < '0' || ch > '9' {
+				return false
+			}
+		}
+	}
+	return r > 1 || g > 1 || b > 1
 }
 
-// parseNamedColor parses CSS named colors.
-func parseNamedColor(s string) (Color, bool) {
-	s = strings.ToLower(strings.TrimSpace(s))
-	if color, ok := namedColors[s]; ok {
-		return color, true
+// newRGBColorSpaceColor converts RGB values in a color() space to a Color.
+// sRGB values inside the gamut give an *RGBA; anything else gives a SpaceColor
+// in the registered Space, so wide-gamut and out-of-range values are not clipped.
+func newRGBColorSpaceColor(space *RGBColorSpace, r, g, b, alpha float64) Color {
+	// Missing (none) values resolve to zero
+	r, g, b, alpha = resolveNone(r), resolveNone(g), resolveNone(b), resolveNone(alpha)
+
+	if space == sRGBSpace && inUnitRange(r) && inUnitRange(g) && inUnitRange(b) {
+		return NewRGBA(r, g, b, alpha)
+	}
+	if s, ok := GetSpace(space.Name); ok {
+		return NewSpaceColor(s, []float64{r, g, b}, alpha)
 	}
-	return nil, false
+
+	// Spaces without a registered Space are kept as unclamped XYZ
+	return space.ConvertRGBToXYZ(r, g, b, alpha)
 }
 
 // namedColors contains CSS named colors.
@@ -0,0 +1,328 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// calcContext resolves the identifiers and percentages inside a calc() expression.
+type calcContext struct {
+	keywords   map[string]float64 // Channel keywords of a relative color (nil outside one)
+	percentRef float64            // Value that 100% resolves to (0 if percentages are not allowed)
+}
+
+// isCalcExpression reports whether s is a CSS math function: calc(), min(), max() or clamp().
+func isCalcExpression(s string) bool {
+	for _, fn := range []string{"calc(", "min(", "max(", "clamp("} {
+		if strings.HasPrefix(s, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// evalCalc evaluates a CSS math expression such as "calc(l + 0.1)", "clamp(0, c * 2, 0.37)"
+// or a bare value like "50%" or "h".
+//
+// Supported syntax:
+//   - Numbers, percentages (resolved against ctx.percentRef) and angles in deg, rad, grad or turn
+//   - Channel keywords from ctx.keywords, plus the constants pi and e
+//   - Operators + - * / with the usual precedence, and parentheses
+//   - Functions calc(), min(), max() and clamp()
+func evalCalc(s string, ctx calcContext) (float64, error) {
+	p := &calcParser{s: strings.ToLower(strings.TrimSpace(s)), ctx: ctx}
+	if p.s == "" {
+		return 0, newParseError(ErrSyntax, s, "empty value")
+	}
+
+	v, err := p.parseSum()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return 0, p.errorf(ErrSyntax, "unexpected %q", p.s[p.pos:])
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, p.errorf(ErrOutOfRange, "result is not a finite number")
+	}
+	return v, nil
+}
+
+// calcParser is a recursive descent parser for CSS math expressions.
+type calcParser struct {
+	s   string
+	pos int
+	ctx calcContext
+}
+
+// errorf returns a ParseError pointing at the current position in the expression.
+func (p *calcParser) errorf(kind error, format string, args ...interface{}) error {
+	e := newParseError(kind, p.s, fmt.Sprintf(format, args...))
+	e.tokenPos = p.pos
+	return e
+}
+
+func (p *calcParser) skipSpace() {
+	for p.pos < len(p.s) && isCalcSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space byte, or 0 at the end of input.
+func (p *calcParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// parseSum parses: product (('+' | '-') product)*
+func (p *calcParser) parseSum() (float64, error) {
+	v, err := p.parseProduct()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return v, nil
+		}
+		p.pos++
+		rhs, err := p.parseProduct()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			v += rhs
+		} else {
+			v -= rhs
+		}
+	}
+}
+
+// parseProduct parses: unary (('*' | '/') unary)*
+func (p *calcParser) parseProduct() (float64, error) {
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return v, nil
+		}
+		p.pos++
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			v *= rhs
+		} else {
+			if rhs == 0 {
+				return 0, p.errorf(ErrOutOfRange, "division by zero")
+			}
+			v /= rhs
+		}
+	}
+}
+
+// parseUnary parses an optionally negated value.
+func (p *calcParser) parseUnary() (float64, error) {
+	switch p.peek() {
+	case '-', '+':
+		// A sign directly followed by a digit belongs to the number literal
+		if p.pos+1 < len(p.s) && isCalcNumberStart(p.s[p.pos+1]) {
+			return p.parseValue()
+		}
+		neg := p.s[p.pos] == '-'
+		p.pos++
+		v, err := p.parseUnary()
+		if neg {
+			v = -v
+		}
+		return v, err
+	}
+	return p.parseValue()
+}
+
+// parseValue parses a number, keyword, parenthesized expression or math function.
+func (p *calcParser) parseValue() (float64, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return 0, p.errorf(ErrSyntax, "unexpected end of expression")
+	case c == '(':
+		p.pos++
+		v, err := p.parseSum()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, p.errorf(ErrSyntax, "missing closing parenthesis")
+		}
+		p.pos++
+		return v, nil
+	case c == '-' || c == '+' || isCalcNumberStart(c):
+		return p.parseNumber()
+	case isCalcIdentStart(c):
+		return p.parseIdent()
+	}
+	return 0, p.errorf(ErrSyntax, "unexpected %q", string(c))
+}
+
+// parseNumber parses a number literal with an optional unit or percent sign.
+func (p *calcParser) parseNumber() (float64, error) {
+	start := p.pos
+	if p.s[p.pos] == '-' || p.s[p.pos] == '+' {
+		p.pos++
+	}
+	for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || p.s[p.pos] == '.') {
+		p.pos++
+	}
+	// Exponent, only when followed by digits so "1e" stays a unit error
+	if p.pos < len(p.s) && p.s[p.pos] == 'e' {
+		end := p.pos + 1
+		if end < len(p.s) && (p.s[end] == '-' || p.s[end] == '+') {
+			end++
+		}
+		if end < len(p.s) && isDigit(p.s[end]) {
+			for end < len(p.s) && isDigit(p.s[end]) {
+				end++
+			}
+			p.pos = end
+		}
+	}
+
+	literal := p.s[start:p.pos]
+	v, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf(ErrBadNumber, "invalid number %q", literal)
+	}
+
+	unitStart := p.pos
+	if p.pos < len(p.s) && p.s[p.pos] == '%' {
+		p.pos++
+		if p.ctx.percentRef == 0 {
+			return 0, p.errorf(ErrBadNumber, "percentage not allowed here")
+		}
+		return v / 100 * p.ctx.percentRef, nil
+	}
+	for p.pos < len(p.s) && isCalcIdentStart(p.s[p.pos]) {
+		p.pos++
+	}
+	unit := p.s[unitStart:p.pos]
+	deg, ok := angleToDegrees(v, unit)
+	if !ok {
+		p.pos = unitStart
+		return 0, p.errorf(ErrUnknownUnit, "unknown unit %q", unit)
+	}
+	return deg, nil
+}
+
+// parseIdent parses a keyword, constant or math function call.
+func (p *calcParser) parseIdent() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (isCalcIdentStart(p.s[p.pos]) || isDigit(p.s[p.pos])) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+
+	if p.pos < len(p.s) && p.s[p.pos] == '(' {
+		p.pos++
+		args, err := p.parseArgs()
+		if err != nil {
+			return 0, err
+		}
+		v, err := p.call(name, args)
+		if e, ok := err.(*ParseError); ok {
+			e.tokenPos = start
+		}
+		return v, err
+	}
+
+	if v, ok := p.ctx.keywords[name]; ok {
+		return v, nil
+	}
+	switch name {
+	case "pi":
+		return math.Pi, nil
+	case "e":
+		return math.E, nil
+	}
+	p.pos = start
+	return 0, p.errorf(ErrSyntax, "unknown keyword %q", name)
+}
+
+// parseArgs parses comma-separated function arguments up to the closing parenthesis.
+func (p *calcParser) parseArgs() ([]float64, error) {
+	var args []float64
+	for {
+		v, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return args, nil
+		default:
+			return nil, p.errorf(ErrSyntax, "missing closing parenthesis")
+		}
+	}
+}
+
+// call evaluates a math function.
+func (p *calcParser) call(name string, args []float64) (float64, error) {
+	switch name {
+	case "calc":
+		if len(args) != 1 {
+			return 0, p.errorf(ErrArgCount, "calc() takes 1 argument, got %d", len(args))
+		}
+		return args[0], nil
+	case "min":
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Min(v, a)
+		}
+		return v, nil
+	case "max":
+		v := args[0]
+		for _, a := range args[1:] {
+			v = math.Max(v, a)
+		}
+		return v, nil
+	case "clamp":
+		if len(args) != 3 {
+			return 0, p.errorf(ErrArgCount, "clamp() takes 3 arguments, got %d", len(args))
+		}
+		// The minimum wins over the maximum, as in CSS
+		return math.Max(args[0], math.Min(args[1], args[2])), nil
+	}
+	return 0, p.errorf(ErrUnknownFunction, "unknown function %s()", name)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isCalcNumberStart(c byte) bool {
+	return isDigit(c) || c == '.'
+}
+
+func isCalcIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || c == '_'
+}
+
+func isCalcSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
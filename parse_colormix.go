@@ -0,0 +1,174 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// interpolationSpaces maps CSS <color-space> names to gradient spaces.
+var interpolationSpaces = map[string]GradientSpace{
+	"srgb":         GradientRGB,
+	"srgb-linear":  GradientSRGBLinear,
+	"display-p3":   GradientDisplayP3,
+	"a98-rgb":      GradientA98RGB,
+	"prophoto-rgb": GradientProPhotoRGB,
+	"rec2020":      GradientRec2020,
+	"lab":         GradientLAB,
+	"oklab":       GradientOKLAB,
+	"xyz":         GradientXYZD65,
+	"xyz-d50":     GradientXYZD50,
+	"xyz-d65":     GradientXYZD65,
+	"hsl":         GradientHSL,
+	"hwb":         GradientHWB,
+	"lch":         GradientLCH,
+	"oklch":       GradientOKLCH,
+}
+
+// hueInterpolationMethods maps CSS <hue-interpolation-method> keywords to HueInterpolation.
+var hueInterpolationMethods = map[string]HueInterpolation{
+	"shorter":    HueShorter,
+	"longer":     HueLonger,
+	"increasing": HueIncreasing,
+	"decreasing": HueDecreasing,
+}
+
+// isPolarGradientSpace reports whether a space has a hue channel.
+func isPolarGradientSpace(space GradientSpace) bool {
+	switch space {
+	case GradientHSL, GradientHWB, GradientLCH, GradientOKLCH:
+		return true
+	}
+	return false
+}
+
+// parseInterpolationMethod parses a CSS <color-interpolation-method>,
+// e.g. "in oklch", "in hsl longer hue" or "in srgb-linear".
+func parseInterpolationMethod(s string) (GradientSpace, HueInterpolation, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 || fields[0] != "in" {
+		return 0, 0, newParseError(ErrSyntax, s, "interpolation method must start with \"in <color-space>\"")
+	}
+
+	space, ok := interpolationSpaces[fields[1]]
+	if !ok {
+		return 0, 0, newParseError(ErrUnknownColorSpace, fields[1], fmt.Sprintf("unknown interpolation color space: %s", fields[1]))
+	}
+
+	switch len(fields) {
+	case 2:
+		return space, HueShorter, nil
+	case 4:
+		hue, ok := hueInterpolationMethods[fields[2]]
+		if !ok || fields[3] != "hue" {
+			return 0, 0, newParseError(ErrSyntax, s, "invalid hue interpolation method")
+		}
+		if !isPolarGradientSpace(space) {
+			return 0, 0, newParseError(ErrSyntax, s, fmt.Sprintf("hue interpolation is not allowed in %s", fields[1]))
+		}
+		return space, hue, nil
+	}
+	return 0, 0, newParseError(ErrSyntax, s, "invalid interpolation method")
+}
+
+// parseColorMix parses the CSS Color 5 color-mix() function:
+//   color-mix(in oklch, red, blue)
+//   color-mix(in oklch longer hue, red 30%, blue)
+//   color-mix(in srgb, 25% red, blue 25%)
+//
+// Percentages are normalized as in the spec: a missing percentage is 100% minus
+// the other, and if both sum to less than 100% the result's alpha is scaled by
+// the sum. Channels are interpolated with premultiplied alpha.
+// The interpolation method may be omitted, in which case OKLAB is used.
+func parseColorMix(s string, opts ParseOptions) (Color, error) {
+	open := strings.Index(s, "(")
+	if open < 0 || matchingParen(s, open) != len(s)-1 {
+		return nil, newParseError(ErrSyntax, s, "invalid function format")
+	}
+
+	parts, starts := splitTopLevelAt(s[open+1:len(s)-1], func(c rune) bool { return c == ',' })
+	for i := range parts {
+		parts[i], starts[i] = trimAt(parts[i], open+1+starts[i])
+	}
+
+	space, hue := GradientOKLAB, HueShorter
+	switch len(parts) {
+	case 2:
+	case 3:
+		var err error
+		space, hue, err = parseInterpolationMethod(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		parts, starts = parts[1:], starts[1:]
+	default:
+		return nil, newParseError(ErrArgCount, "", "color-mix() requires an interpolation method and 2 colors")
+	}
+
+	c1, p1, has1, err := parseMixComponent(parts[0], opts)
+	if err != nil {
+		return nil, shiftError(err, starts[0])
+	}
+	c2, p2, has2, err := parseMixComponent(parts[1], opts)
+	if err != nil {
+		return nil, shiftError(err, starts[1])
+	}
+
+	switch {
+	case !has1 && !has2:
+		p1, p2 = 0.5, 0.5
+	case !has2:
+		p2 = 1 - p1
+	case !has1:
+		p1 = 1 - p2
+	}
+
+	sum := p1 + p2
+	if sum <= 0 {
+		return nil, newParseError(ErrSyntax, s, "color-mix() percentages cannot both be 0%")
+	}
+
+	mixed := mixInSpace(c1, c2, p2/sum, space, mixOptions{hue: hue, premultiplied: true})
+
+	// Percentages summing to less than 100% make the result more transparent
+	if sum < 1-1e-9 {
+		mixed = mixed.WithAlpha(mixed.Alpha() * sum)
+	}
+	return mixed, nil
+}
+
+// parseMixComponent parses one color-mix() argument: a color with an optional
+// percentage before or after it, e.g. "red 30%" or "30% red".
+// Errors are positioned within s.
+func parseMixComponent(s string, opts ParseOptions) (c Color, pct float64, hasPct bool, err error) {
+	var colorArg string
+	var colorAt int
+	toks, starts := splitTopLevelAt(s, unicode.IsSpace)
+	for i, tok := range toks {
+		switch {
+		case tok == "":
+		case strings.HasSuffix(tok, "%") && !hasPct:
+			pct, err = parseNumber(tok)
+			if err != nil {
+				return nil, 0, false, shiftError(err, starts[i])
+			}
+			if pct < 0 || pct > 1 {
+				return nil, 0, false, newParseError(ErrOutOfRange, tok, "color-mix() percentage out of range (0-100%)").at(starts[i])
+			}
+			hasPct = true
+		case colorArg == "":
+			colorArg, colorAt = tok, starts[i]
+		default:
+			return nil, 0, false, newParseError(ErrSyntax, tok, "unexpected value in color-mix() argument").at(starts[i])
+		}
+	}
+
+	if colorArg == "" {
+		return nil, 0, false, newParseError(ErrSyntax, s, "color-mix() argument requires a color").at(0)
+	}
+	c, err = parseColor(colorArg, opts)
+	if err != nil {
+		return nil, 0, false, shiftError(err, colorAt)
+	}
+	return c, pct, hasPct, nil
+}
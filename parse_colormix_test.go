@@ -0,0 +1,238 @@
+package color
+
+import (
+	"testing"
+)
+
+func TestParseColorMix(t *testing.T) {
+	red, blue := RGB(1, 0, 0), RGB(0, 0, 1)
+
+	tests := []struct {
+		input string
+		want  Color
+	}{
+		{"color-mix(in srgb, red, blue)", NewRGBA(0.5, 0, 0.5, 1)},
+		{"color-mix(in srgb, red 30%, blue)", NewRGBA(0.3, 0, 0.7, 1)},
+		{"color-mix(in srgb, red, blue 30%)", NewRGBA(0.7, 0, 0.3, 1)},
+		{"color-mix(in srgb, 30% red, blue)", NewRGBA(0.3, 0, 0.7, 1)},
+		{"color-mix(in srgb, red 60%, blue 60%)", NewRGBA(0.5, 0, 0.5, 1)}, // Normalized to 50/50
+		{"color-mix(in oklch, red, blue)", MixInSpace(red, blue, 0.5, GradientOKLCH)},
+		{"color-mix(in oklab, red, blue)", MixInSpace(red, blue, 0.5, GradientOKLAB)},
+		{"color-mix(in lab, red, blue)", MixInSpace(red, blue, 0.5, GradientLAB)},
+		{"color-mix(in lch, red, blue)", MixInSpace(red, blue, 0.5, GradientLCH)},
+		{"color-mix(in hsl, red, blue)", MixInSpace(red, blue, 0.5, GradientHSL)},
+		{"color-mix(in hwb, red, blue)", MixInSpace(red, blue, 0.5, GradientHWB)},
+		{"color-mix(in xyz, red, blue)", MixInSpace(red, blue, 0.5, GradientXYZD65)},
+		{"color-mix(in xyz-d50, red, blue)", MixInSpace(red, blue, 0.5, GradientXYZD50)},
+		{"color-mix(in srgb-linear, red, blue)", MixInSpace(red, blue, 0.5, GradientSRGBLinear)},
+		{"color-mix(in display-p3, red, blue)", MixInSpace(red, blue, 0.5, GradientDisplayP3)},
+		{"color-mix(in a98-rgb, red, blue)", MixInSpace(red, blue, 0.5, GradientA98RGB)},
+		{"color-mix(in prophoto-rgb, red, blue)", MixInSpace(red, blue, 0.5, GradientProPhotoRGB)},
+		{"color-mix(in rec2020, red, blue)", MixInSpace(red, blue, 0.5, GradientRec2020)},
+		{"color-mix(in xyz-d65, red, blue)", MixInSpace(red, blue, 0.5, GradientXYZD65)},
+		{"color-mix(in oklch longer hue, red, blue)", MixInSpaceWithHue(red, blue, 0.5, GradientOKLCH, HueLonger)},
+		{"color-mix(in hsl increasing hue, red, blue)", MixInSpaceWithHue(red, blue, 0.5, GradientHSL, HueIncreasing)},
+		{"color-mix(red, blue)", MixInSpace(red, blue, 0.5, GradientOKLAB)},
+		{"color-mix(in srgb, rgb(255 0 0), color-mix(in srgb, blue, blue))", NewRGBA(0.5, 0, 0.5, 1)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		if d := DeltaEOK(got, tt.want); d > 1e-6 {
+			t.Errorf("ParseColor(%q) differs from expected by %f", tt.input, d)
+		}
+	}
+}
+
+func TestColorMixRGBSpaces(t *testing.T) {
+	// Each predefined RGB space mixes the encoded channels of that space
+	red, blue := RGB(1, 0, 0), RGB(0, 0, 1)
+	for name, space := range map[string]Space{
+		"srgb-linear":  SRGBLinearSpace,
+		"display-p3":   DisplayP3Space,
+		"a98-rgb":      A98RGBSpace,
+		"prophoto-rgb": ProPhotoRGBSpace,
+		"rec2020":      Rec2020Space,
+	} {
+		got, err := ParseColor("color-mix(in " + name + ", red 25%, blue)")
+		if err != nil {
+			t.Errorf("color-mix in %s: %v", name, err)
+			continue
+		}
+		in := func(c Color) []float64 {
+			xyz := ToXYZ(c)
+			return space.FromXYZ(xyz.X, xyz.Y, xyz.Z)
+		}
+		sc, ok := got.(SpaceColor)
+		if !ok || sc.Space() != space {
+			t.Errorf("color-mix in %s = %T, want a color in %s", name, got, name)
+			continue
+		}
+		r, b, ch := in(red), in(blue), sc.Channels()
+		for i := range ch {
+			if want := 0.25*r[i] + 0.75*b[i]; abs(ch[i]-want) > 1e-9 {
+				t.Errorf("color-mix in %s: channel %d = %f, want %f", name, i, ch[i], want)
+			}
+		}
+	}
+}
+
+func TestParseColorMixOutOfGamut(t *testing.T) {
+	// Display P3 green has a negative sRGB red; it must not be clipped to 0 before mixing
+	p3Green, _ := ParseColor("color(display-p3 0 1 0)")
+	r1, g1, _, _ := UnclampedRGBA(p3Green)
+	if r1 >= 0 {
+		t.Fatalf("Display P3 green sRGB red = %f, want negative", r1)
+	}
+
+	got, err := ParseColor("color-mix(in srgb, color(display-p3 0 1 0), red)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	r, g, b, _ := got.RGBA()
+	if abs(r-(r1+1)/2) > 1e-6 || abs(g-clamp01(g1/2)) > 1e-6 || b != 0 {
+		t.Errorf("Got rgb(%f %f %f), want rgb(%f %f 0)", r, g, b, (r1+1)/2, clamp01(g1/2))
+	}
+}
+
+func TestParseColorMixHue(t *testing.T) {
+	// Red (hue 0) and blue (hue 240) in HSL: shorter goes through magenta (300),
+	// longer through green (120)
+	tests := []struct {
+		method string
+		hue    float64
+	}{
+		{"shorter", 300},
+		{"longer", 120},
+		{"increasing", 120},
+		{"decreasing", 300},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColor("color-mix(in hsl " + tt.method + " hue, red, blue)")
+		if err != nil {
+			t.Errorf("%s: error %v", tt.method, err)
+			continue
+		}
+		if h := c.(*HSL).H; abs(h-tt.hue) > 1e-6 {
+			t.Errorf("%s hue = %f, want %f", tt.method, h, tt.hue)
+		}
+	}
+}
+
+func TestParseColorMixAlpha(t *testing.T) {
+	// Percentages summing to less than 100% scale alpha
+	c, err := ParseColor("color-mix(in srgb, red 30%, blue 20%)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if abs(c.Alpha()-0.5) > 1e-9 {
+		t.Errorf("Alpha = %f, want 0.5", c.Alpha())
+	}
+	r, _, b, _ := c.RGBA()
+	if abs(r-0.6) > 1e-9 || abs(b-0.4) > 1e-9 {
+		t.Errorf("Got r=%f b=%f, want r=0.6 b=0.4", r, b)
+	}
+
+	// Premultiplied interpolation: a transparent color contributes no channel values
+	c, err = ParseColor("color-mix(in srgb, red, transparent)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	r, g, b, a := c.RGBA()
+	if abs(r-1) > 1e-9 || g != 0 || b != 0 || abs(a-0.5) > 1e-9 {
+		t.Errorf("Got rgba(%f %f %f %f), want rgba(1 0 0 0.5)", r, g, b, a)
+	}
+
+	// Spec example: color-mix(in srgb, rgb(100% 0% 0% / 0.7) 25%, rgb(0% 100% 0% / 0.2))
+	// gives rgb(53.85% 46.15% 0% / 0.325)
+	c, err = ParseColor("color-mix(in srgb, rgb(100% 0% 0% / 0.7) 25%, rgb(0% 100% 0% / 0.2))")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	r, g, b, a = c.RGBA()
+	if abs(r-0.5385) > 1e-4 || abs(g-0.4615) > 1e-4 || b != 0 || abs(a-0.325) > 1e-9 {
+		t.Errorf("Got rgba(%f %f %f %f), want rgba(0.5385 0.4615 0 0.325)", r, g, b, a)
+	}
+}
+
+func TestParseColorMixErrors(t *testing.T) {
+	invalid := []string{
+		"color-mix(in srgb, red)",                     // One color
+		"color-mix(in srgb, red, blue, green)",        // Three colors
+		"color-mix(in cmyk, red, blue)",               // Unknown space
+		"color-mix(srgb, red, blue)",                  // Missing "in"
+		"color-mix(in srgb longer hue, red, blue)",    // Hue method in rectangular space
+		"color-mix(in oklch sideways hue, red, blue)", // Unknown hue method
+		"color-mix(in oklch longer, red, blue)",       // Missing "hue"
+		"color-mix(in srgb, red 0%, blue 0%)",         // Zero sum
+		"color-mix(in srgb, red 150%, blue)",          // Out of range
+		"color-mix(in srgb, red -10%, blue)",          // Negative
+		"color-mix(in srgb, red 10% 20%, blue)",       // Two percentages
+		"color-mix(in srgb, 50%, blue)",               // Missing color
+		"color-mix(in srgb, notacolor, blue)",         // Bad color
+		"color-mix(in srgb, red, blue",                // Unbalanced
+	}
+
+	for _, input := range invalid {
+		if _, err := ParseColor(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestMixInSpaceWithHue(t *testing.T) {
+	red, blue := RGB(1, 0, 0), RGB(0, 0, 1)
+
+	// HueShorter matches MixInSpace
+	a := MixInSpace(red, blue, 0.3, GradientOKLCH)
+	b := MixInSpaceWithHue(red, blue, 0.3, GradientOKLCH, HueShorter)
+	if DeltaEOK(a, b) > 1e-12 {
+		t.Error("MixInSpaceWithHue(HueShorter) should match MixInSpace")
+	}
+
+	// HueLonger goes the other way around
+	shorter := ToOKLCH(MixInSpaceWithHue(red, blue, 0.5, GradientLCH, HueShorter))
+	longer := ToOKLCH(MixInSpaceWithHue(red, blue, 0.5, GradientLCH, HueLonger))
+	if DeltaEOK(shorter, longer) < 0.1 {
+		t.Error("HueLonger should produce a different color than HueShorter")
+	}
+
+	// Rectangular spaces ignore the hue method
+	a = MixInSpaceWithHue(red, blue, 0.5, GradientOKLAB, HueLonger)
+	b = MixInSpace(red, blue, 0.5, GradientOKLAB)
+	if DeltaEOK(a, b) > 1e-12 {
+		t.Error("Hue method should not affect OKLAB mixing")
+	}
+}
+
+func TestMixInSpaceNewSpaces(t *testing.T) {
+	red, blue := RGB(1, 0, 0), RGB(0, 0, 1)
+
+	// Linear-light mixing and XYZ mixing are linear transforms of each other
+	linear := MixInSpace(red, blue, 0.5, GradientSRGBLinear)
+	xyz := MixInSpace(red, blue, 0.5, GradientXYZD65)
+	xyz50 := MixInSpace(red, blue, 0.5, GradientXYZD50)
+	if DeltaEOK(linear, xyz) > 1e-6 || DeltaEOK(xyz, xyz50) > 1e-6 {
+		t.Error("Linear sRGB, XYZ D65 and XYZ D50 mixes should agree")
+	}
+
+	// Linear-light mixing is brighter than gamma-encoded mixing
+	if ToOKLCH(linear).L <= ToOKLCH(MixInSpace(red, blue, 0.5, GradientRGB)).L {
+		t.Error("Linear sRGB mix should be lighter than sRGB mix")
+	}
+
+	// Endpoints are preserved in every space
+	for space := GradientRGB; space <= GradientHWB; space++ {
+		if d := DeltaEOK(MixInSpace(red, blue, 0, space), red); d > 1e-4 {
+			t.Errorf("Space %d: weight 0 differs from start by %f", space, d)
+		}
+		if d := DeltaEOK(MixInSpace(red, blue, 1, space), blue); d > 1e-4 {
+			t.Errorf("Space %d: weight 1 differs from end by %f", space, d)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors for ParseError.Err. Match them with errors.Is:
+//   _, err := color.ParseColor("rgb(300 0 0)")
+//   errors.Is(err, color.ErrOutOfRange)  // true
+var (
+	ErrEmpty             = errors.New("empty color string")
+	ErrSyntax            = errors.New("invalid color syntax")
+	ErrUnknownName       = errors.New("unknown color name")
+	ErrUnknownFunction   = errors.New("unknown color function")
+	ErrUnknownColorSpace = errors.New("unknown color space")
+	ErrArgCount          = errors.New("wrong number of arguments")
+	ErrOutOfRange        = errors.New("value out of range")
+	ErrBadHex            = errors.New("invalid hex color")
+	ErrBadNumber         = errors.New("invalid number")
+	ErrUnknownUnit       = errors.New("unknown unit")
+)
+
+// ParseError describes why and where a color string could not be parsed.
+//
+// Offset is the byte offset in Input of the first character of the failing
+// token, so a caller can point at it:
+//   fmt.Println(pe.Input)
+//   fmt.Println(strings.Repeat(" ", pe.Offset) + "^")
+type ParseError struct {
+	Input    string // String passed to ParseColor
+	Offset   int    // Byte offset of the failing token in Input
+	Token    string // Failing token, e.g. "300" or "#12g"
+	Function string // CSS function being parsed, e.g. "rgb" (empty outside a function)
+	Channel  string // Channel being parsed, e.g. "r", "h" or "alpha" (empty if none)
+	Reason   string // Human-readable description
+	Err      error  // Sentinel error, e.g. ErrOutOfRange
+
+	tokenPos int  // Offset of the failure within Token
+	arg      int  // 1 + index of the failing argument of the function, 0 if unknown
+	located  bool // Offset is set, relative to the string being parsed
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse color %q: %s", e.Input, e.Reason)
+}
+
+// Unwrap returns the sentinel error, so errors.Is works with ErrOutOfRange and friends.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError creates a ParseError for a token; Input and Offset are set
+// by ParseColor once the whole string is known.
+func newParseError(err error, token, reason string) *ParseError {
+	return &ParseError{Input: token, Token: token, Reason: reason, Err: err}
+}
+
+// newArgError creates a ParseError for the argument args[i] of a color function.
+func newArgError(err error, args []string, i int, reason string) *ParseError {
+	e := newParseError(err, args[i], reason)
+	e.arg = i + 1
+	return e
+}
+
+// argError records that err was returned while parsing args[i] of a color
+// function, and returns it. An error in a nested color, such as the origin of
+// a relative color, keeps its position within the argument.
+func argError(err error, i int) error {
+	if e, ok := err.(*ParseError); ok && e.arg == 0 {
+		e.arg = i + 1
+		if e.located {
+			e.tokenPos, e.located = e.Offset, false
+		}
+	}
+	return err
+}
+
+// argsFrom adjusts an error from a parser given args[first:] of a color
+// function to the full argument list, and returns it.
+func argsFrom(err error, first int) error {
+	if e, ok := err.(*ParseError); ok && e.arg > 0 {
+		e.arg += first
+	}
+	return err
+}
+
+// functionChannels names the channels of each CSS function, in argument order.
+var functionChannels = map[string][]string{
+	"rgb":   {"r", "g", "b", "alpha"},
+	"rgba":  {"r", "g", "b", "alpha"},
+	"hsl":   {"h", "s", "l", "alpha"},
+	"hsla":  {"h", "s", "l", "alpha"},
+	"hsv":   {"h", "s", "v", "alpha"},
+	"hsva":  {"h", "s", "v", "alpha"},
+	"hwb":   {"h", "w", "b", "alpha"},
+	"lab":   {"l", "a", "b", "alpha"},
+	"lch":   {"l", "c", "h", "alpha"},
+	"oklab": {"l", "a", "b", "alpha"},
+	"oklch": {"l", "c", "h", "alpha"},
+	"xyz":   {"x", "y", "z", "alpha"},
+}
+
+// setContext records the function being parsed and, for an error in one of
+// its arguments, the channel name and the argument's position: offsets are
+// the byte offsets of args in the function string. Inner functions (an origin
+// color or a color-mix() argument) keep their own function and channel.
+func (e *ParseError) setContext(funcName string, args []string, offsets []int) {
+	if i := e.arg - 1; i >= 0 && i < len(offsets) {
+		e.arg = 0
+		e.Offset, e.located = offsets[i]+e.tokenPos, true
+		if e.Function == "" {
+			e.Function = funcName
+			e.Channel = channelName(funcName, args, i)
+		}
+		return
+	}
+	if e.Function == "" {
+		e.Function = funcName
+	}
+}
+
+// channelName returns the name of the channel given by args[i] of a color
+// function, or "" if args[i] is not a channel.
+func channelName(funcName string, args []string, i int) string {
+	// Skip "from <color>" in relative syntax, and the space name in color()
+	skip := 0
+	if len(args) > 1 && args[0] == "from" {
+		skip = 2
+	}
+	channels := functionChannels[funcName]
+	if funcName == "color" && len(args) > skip {
+		channels = []string{"r", "g", "b", "alpha"}
+		if strings.HasPrefix(args[skip], "xyz") {
+			channels = functionChannels["xyz"]
+		} else if space, ok := GetSpace(args[skip]); ok {
+			channels = channels[:0]
+			for _, name := range space.ChannelNames() {
+				channels = append(channels, strings.ToLower(name))
+			}
+			channels = append(channels, "alpha")
+		}
+		skip++
+	}
+
+	if i < skip || i-skip >= len(channels) {
+		return ""
+	}
+	return channels[i-skip]
+}
+
+// locate positions an error within s, the string being parsed, unless its
+// position is already known: at the failing token, searched for after the
+// function name, or at the function's arguments when there is no token.
+func (e *ParseError) locate(s string) {
+	if e.located {
+		return
+	}
+	e.located = true
+	lower := strings.ToLower(s)
+
+	from := 0
+	if e.Function != "" {
+		if i := strings.Index(lower, e.Function+"("); i >= 0 {
+			from = i + len(e.Function) + 1
+		}
+	}
+	if e.Token == "" {
+		e.Offset = from
+		return
+	}
+
+	token := strings.ToLower(e.Token)
+	if i := indexToken(lower, token, from); i >= 0 {
+		e.Offset = i + e.tokenPos
+	} else if i := indexToken(lower, token, 0); i >= 0 {
+		e.Offset = i + e.tokenPos
+	} else {
+		e.Offset = from
+	}
+}
+
+// shift moves a located error from a substring to the string containing it,
+// where the substring starts at offset.
+func (e *ParseError) shift(offset int) {
+	if e.located {
+		e.Offset += offset
+	}
+}
+
+// at positions the error at offset in the string being parsed, and returns it.
+func (e *ParseError) at(offset int) *ParseError {
+	e.Offset, e.located = offset+e.tokenPos, true
+	return e
+}
+
+// shiftError moves a ParseError from a substring to the string containing it,
+// as shift does, and returns it. An error not yet located is positioned
+// within its token first.
+func shiftError(err error, offset int) error {
+	if e, ok := err.(*ParseError); ok {
+		if !e.located {
+			e.locate(e.Input)
+		}
+		e.shift(offset)
+	}
+	return err
+}
+
+// indexToken returns the index of the first occurrence of token in s at or
+// after from that is not part of a longer argument, or -1.
+func indexToken(s, token string, from int) int {
+	for from <= len(s) {
+		i := strings.Index(s[from:], token)
+		if i < 0 {
+			return -1
+		}
+		start, end := from+i, from+i+len(token)
+		if (start == 0 || isTokenBoundary(s[start-1])) && (end == len(s) || isTokenBoundary(s[end])) {
+			return start
+		}
+		from = start + 1
+	}
+	return -1
+}
+
+// isTokenBoundary reports whether c can separate color function arguments.
+func isTokenBoundary(c byte) bool {
+	return isCalcSpace(c) || c == '(' || c == ')' || c == ',' || c == '/'
+}
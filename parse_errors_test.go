@@ -0,0 +1,101 @@
+package color
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrorDetails(t *testing.T) {
+	tests := []struct {
+		input    string
+		kind     error
+		offset   int
+		token    string
+		function string
+		channel  string
+	}{
+		{"", ErrEmpty, 0, "", "", ""},
+		{"notacolor", ErrUnknownName, 0, "notacolor", "", ""},
+		{"#12g", ErrBadHex, 3, "#12g", "", ""},
+		{"  #12345", ErrBadHex, 2, "#12345", "", ""},
+		{"foo(1 2 3)", ErrUnknownFunction, 0, "foo", "foo", ""},
+		{"rgb(10 300 0)", ErrOutOfRange, 7, "300", "rgb", "g"},
+		{"rgb(255, 255, 256)", ErrOutOfRange, 14, "256", "rgb", "b"},
+		{"rgb(1 2)", ErrArgCount, 4, "", "rgb", ""},
+		{"hsl(120 50% 5x%)", ErrBadNumber, 12, "5x%", "hsl", "l"},
+		{"oklch(0.7 0.1 120 / abc)", ErrBadNumber, 20, "abc", "oklch", "alpha"},
+		{"oklch(0.7 0.1 120foo)", ErrUnknownUnit, 17, "120foo", "oklch", "h"},
+		{"oklch(0.7 -0.1 120)", ErrOutOfRange, 10, "-0.1", "oklch", "c"},
+		{"color(display-p4 1 0 0)", ErrUnknownColorSpace, 6, "display-p4", "color", ""},
+		{"color(display-p3 1 x 0)", ErrBadNumber, 19, "x", "color", "g"},
+		{"lab(50 calc(10 +) 0)", ErrSyntax, 16, "calc(10 +)", "lab", "a"},
+		{"oklch(from red l c calc(h * q))", ErrSyntax, 28, "calc(h * q)", "oklch", "h"},
+		{"oklch(from rgb(300 0 0) l c h)", ErrOutOfRange, 15, "300", "rgb", "r"},
+		{"color-mix(in foo, red, blue)", ErrUnknownColorSpace, 13, "foo", "color-mix", ""},
+		// Repeated tokens are reported where they fail, not where they first appear
+		{"hsl(50 50% 50)", ErrOutOfRange, 11, "50", "hsl", "l"},
+		{"hsl(50, 50%, 50)", ErrOutOfRange, 13, "50", "hsl", "l"},
+		{"color-mix(in srgb, rgb(1 2 3), rgb(1 2 x))", ErrBadNumber, 39, "x", "rgb", "b"},
+		{"color-mix(in srgb, red 150%, red 150%)", ErrOutOfRange, 23, "150%", "color-mix", ""},
+		{"oklch(from oklch(0.5 0.5 0.5) l c 0.5x)", ErrUnknownUnit, 37, "0.5x", "oklch", "h"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseColor(tt.input)
+		if err == nil {
+			t.Errorf("ParseColor(%q) succeeded, want error", tt.input)
+			continue
+		}
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("ParseColor(%q) error %v is not %v", tt.input, err, tt.kind)
+		}
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseColor(%q) error %T is not a *ParseError", tt.input, err)
+			continue
+		}
+		if pe.Input != tt.input {
+			t.Errorf("ParseColor(%q): Input = %q", tt.input, pe.Input)
+		}
+		if pe.Offset != tt.offset {
+			t.Errorf("ParseColor(%q): Offset = %d, want %d", tt.input, pe.Offset, tt.offset)
+		}
+		if pe.Token != tt.token {
+			t.Errorf("ParseColor(%q): Token = %q, want %q", tt.input, pe.Token, tt.token)
+		}
+		if pe.Function != tt.function {
+			t.Errorf("ParseColor(%q): Function = %q, want %q", tt.input, pe.Function, tt.function)
+		}
+		if pe.Channel != tt.channel {
+			t.Errorf("ParseColor(%q): Channel = %q, want %q", tt.input, pe.Channel, tt.channel)
+		}
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := ParseColor("rgb(10 300 0)")
+	want := `cannot parse color "rgb(10 300 0)": RGB green component out of range (0-255)`
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
+
+func TestHexToRGBError(t *testing.T) {
+	_, err := HexToRGB("#ff00zz")
+	if !errors.Is(err, ErrBadHex) {
+		t.Fatalf("HexToRGB error %v is not ErrBadHex", err)
+	}
+	var he *HexParseError
+	if !errors.As(err, &he) {
+		t.Fatalf("HexToRGB error %T is not a *HexParseError", err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Offset != 5 {
+		t.Errorf("ParseError = %+v, want Offset 5", pe)
+	}
+
+	if !errors.Is(&HexParseError{hex: "zz"}, ErrBadHex) {
+		t.Error("HexParseError should unwrap to ErrBadHex")
+	}
+}
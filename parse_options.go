@@ -0,0 +1,158 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseMode selects which syntaxes ParseColorWithOptions accepts.
+type ParseMode int
+
+const (
+	// ParseDefault accepts CSS plus the extensions ParseColor has always
+	// accepted: hex without '#', hsv()/hsva(), xyz(), color() space aliases
+	// such as "prophoto", and lab()/lch() lightness written as a 0-1 fraction.
+	ParseDefault ParseMode = iota
+
+	// ParseStrict accepts only CSS Color 4/5 syntax and rejects the
+	// extensions of ParseDefault. Channels are read as CSS computes them:
+	// rgb() numbers are always 0-255, hsl() and hwb() percentages may be
+	// unitless, out-of-range channels are clamped, and lab()/lch()
+	// lightness is always 0-100.
+	ParseStrict
+
+	// ParseLenient accepts everything ParseDefault does, plus syntaxes
+	// common in design tools and code:
+	//   - "0xRRGGBB" and "0xAARRGGBB" (integer literals, alpha first)
+	//   - bare channel lists: "255,0,0", "255 0 0", "255, 0, 0, 0.5"
+	ParseLenient
+)
+
+// ParseOptions configures ParseColorWithOptions. The zero value behaves like ParseColor.
+type ParseOptions struct {
+	// Mode selects strict, default or lenient syntax.
+	Mode ParseMode
+
+	// Names are the dictionaries searched for color names, in order.
+	// nil means CSSNames; an empty, non-nil slice disables color names.
+	Names []*Dictionary
+
+	// HexAlphaFirst reads 4- and 8-digit hex as #ARGB and #AARRGGBB, the order
+	// used by Android and .NET, instead of the CSS #RGBA and #RRGGBBAA.
+	// It has no effect in ParseStrict mode.
+	HexAlphaFirst bool
+}
+
+// cssFunctions are the color functions defined by CSS Color 4 and 5.
+var cssFunctions = map[string]bool{
+	"rgb": true, "rgba": true, "hsl": true, "hsla": true, "hwb": true,
+	"lab": true, "lch": true, "oklab": true, "oklch": true, "color": true,
+}
+
+// cssPredefinedSpaces are the <predefined-rgb> and <xyz-space> identifiers of color().
+var cssPredefinedSpaces = map[string]bool{
+	"srgb": true, "srgb-linear": true, "display-p3": true, "a98-rgb": true,
+	"prophoto-rgb": true, "rec2020": true, "xyz": true, "xyz-d50": true, "xyz-d65": true,
+}
+
+// ParseColorWithOptions parses a color string like ParseColor, with control
+// over the accepted syntax and the color names.
+//
+// Example:
+//   // Reject anything a browser would not accept
+//   c, err := color.ParseColorWithOptions(s, color.ParseOptions{Mode: color.ParseStrict})
+//
+//   // Read colors exported from an Android project
+//   c, err := color.ParseColorWithOptions("#80ff0000", color.ParseOptions{
+//       Mode:          color.ParseLenient,
+//       HexAlphaFirst: true,
+//   })
+func ParseColorWithOptions(s string, opts ParseOptions) (Color, error) {
+	c, err := parseColor(s, opts)
+	if e, ok := err.(*ParseError); ok {
+		e.Input = s
+	}
+	return c, err
+}
+
+// lookupName looks s up in the configured dictionaries.
+func (opts ParseOptions) lookupName(s string) (Color, bool) {
+	if opts.Names == nil {
+		return CSSNames.Lookup(s)
+	}
+	for _, d := range opts.Names {
+		if c, ok := d.Lookup(s); ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// parseHex parses a hex color, honoring HexAlphaFirst.
+func parseHex(s string, opts ParseOptions) (Color, error) {
+	if !opts.HexAlphaFirst || opts.Mode == ParseStrict {
+		return parseHexRGB(s)
+	}
+
+	digits := strings.TrimPrefix(s, "#")
+	switch len(digits) {
+	case 4: // #ARGB -> #RGBA
+		digits = digits[1:] + digits[:1]
+	case 8: // #AARRGGBB -> #RRGGBBAA
+		digits = digits[2:] + digits[:2]
+	default:
+		return parseHexRGB(s)
+	}
+
+	c, err := hexToRGB(digits)
+	if err != nil {
+		// Report the error against the original string
+		return nil, newParseError(ErrBadHex, s, err.Reason)
+	}
+	return c, nil
+}
+
+// parseHexRGB parses a hex color, reporting errors as *ParseError.
+func parseHexRGB(s string) (Color, error) {
+	c, err := hexToRGB(s)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseLenient parses the extra syntaxes of ParseLenient.
+// ok is false when s is not one of them.
+func parseLenient(s string) (c Color, ok bool, err error) {
+	lower := strings.ToLower(s)
+
+	// 0xRRGGBB or 0xAARRGGBB
+	if strings.HasPrefix(lower, "0x") {
+		digits := lower[2:]
+		if len(digits) == 8 {
+			digits = digits[2:] + digits[:2]
+		}
+		if len(digits) != 6 && len(digits) != 8 {
+			return nil, true, newParseError(ErrBadHex, s, "hex literal must be 0xRRGGBB or 0xAARRGGBB")
+		}
+		rgba, perr := hexToRGB(digits)
+		if perr != nil {
+			return nil, true, newParseError(ErrBadHex, s, fmt.Sprintf("invalid hex literal %q", s))
+		}
+		return rgba, true, nil
+	}
+
+	// Bare channel list: "255,0,0" or "255 0 0 / 0.5"
+	if strings.ContainsAny(s, "(#") || !isCalcNumberStart(s[0]) {
+		return nil, false, nil
+	}
+	args, offsets := parseArgs(lower)
+	if len(args) < 3 || len(args) > 4 {
+		return nil, false, nil
+	}
+	c, err = parseRGB(args, false, ParseOptions{})
+	if e, ok := err.(*ParseError); ok {
+		e.setContext("rgb", args, offsets)
+	}
+	return c, true, err
+}
@@ -0,0 +1,223 @@
+package color
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStrict(t *testing.T) {
+	strict := ParseOptions{Mode: ParseStrict}
+
+	accepted := []string{
+		"#ff0000",
+		"red",
+		"rgb(255 0 0)",
+		"rgba(255, 0, 0, 0.5)",
+		"hsl(120 100% 50%)",
+		"hwb(200 10% 20%)",
+		"lab(50% 20 -30)",
+		"oklch(0.7 0.15 240)",
+		"color(display-p3 1 0 0)",
+		"color(xyz-d65 0.3 0.4 0.5)",
+		"oklch(from red l c h)",
+		"color-mix(in oklch, red, blue)",
+	}
+	for _, s := range accepted {
+		if _, err := ParseColorWithOptions(s, strict); err != nil {
+			t.Errorf("strict ParseColorWithOptions(%q) error: %v", s, err)
+		}
+	}
+
+	rejected := []struct {
+		input string
+		kind  error
+	}{
+		{"ff0000", ErrUnknownName},
+		{"hsv(0, 100%, 100%)", ErrUnknownFunction},
+		{"xyz(0.3 0.4 0.5)", ErrUnknownFunction},
+		{"color(prophoto 1 0 0)", ErrUnknownColorSpace},
+		{"color(from red rec-2020 r g b)", ErrUnknownColorSpace},
+		{"color-mix(in oklch, red, hsv(0, 1, 1))", ErrUnknownFunction},
+	}
+	for _, tt := range rejected {
+		_, err := ParseColorWithOptions(tt.input, strict)
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("strict ParseColorWithOptions(%q) error = %v, want %v", tt.input, err, tt.kind)
+		}
+		if _, err := ParseColor(tt.input); err != nil {
+			t.Errorf("ParseColor(%q) should still accept it: %v", tt.input, err)
+		}
+	}
+}
+
+func TestParseStrictLabLightness(t *testing.T) {
+	// CSS reads a unitless lab() lightness literally; the default mode treats
+	// values up to 1 as a fraction
+	strict, err := ParseColorWithOptions("lab(0.5 0 0)", ParseOptions{Mode: ParseStrict})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := strict.(*LAB).L; l != 0.5 {
+		t.Errorf("strict lab L = %v, want 0.5", l)
+	}
+
+	def, _ := ParseColor("lab(0.5 0 0)")
+	if l := def.(*LAB).L; l != 50 {
+		t.Errorf("default lab L = %v, want 50", l)
+	}
+}
+
+func TestParseStrictChannels(t *testing.T) {
+	// CSS reads rgb() numbers as 0-255, allows unitless hsl()/hwb()
+	// percentages and clamps channels at computed-value time
+	tests := []struct {
+		input      string
+		r, g, b, a float64
+	}{
+		{"rgb(1 0 0)", 1.0 / 255, 0, 0, 1},
+		{"rgb(0.5 0.5 0.5)", 0.5 / 255, 0.5 / 255, 0.5 / 255, 1},
+		{"rgb(300 0 0)", 1, 0, 0, 1},
+		{"rgb(-20 128 0)", 0, 128.0 / 255, 0, 1},
+		{"rgb(50% 0 120%)", 0.5, 0, 1, 1},
+		{"rgba(255, 0, 0, 1.5)", 1, 0, 0, 1},
+		{"hsl(0 100 50)", 1, 0, 0, 1},
+		{"hsl(0 150 50)", 1, 0, 0, 1},
+		{"hsl(120 100% 25)", 0, 0.5, 0, 1},
+		{"hwb(0 0 0)", 1, 0, 0, 1},
+		{"hwb(240 20 120)", 1.0 / 6, 1.0 / 6, 1.0 / 6, 1},
+	}
+	strict := ParseOptions{Mode: ParseStrict}
+	for _, tt := range tests {
+		c, err := ParseColorWithOptions(tt.input, strict)
+		if err != nil {
+			t.Errorf("strict ParseColorWithOptions(%q) error: %v", tt.input, err)
+			continue
+		}
+		r, g, b, a := c.RGBA()
+		if !floatNear(r, tt.r, 1e-9) || !floatNear(g, tt.g, 1e-9) || !floatNear(b, tt.b, 1e-9) || a != tt.a {
+			t.Errorf("strict %q = %g, %g, %g, %g, want %g, %g, %g, %g", tt.input, r, g, b, a, tt.r, tt.g, tt.b, tt.a)
+		}
+	}
+
+	// The default mode still reads channels up to 1 as 0-1
+	def, err := ParseColor("rgb(1 0 0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, _, _ := def.RGBA(); r != 1 {
+		t.Errorf("default rgb(1 0 0) red = %g, want 1", r)
+	}
+	if _, err := ParseColor("rgb(300 0 0)"); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("default rgb(300 0 0) error = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	lenient := ParseOptions{Mode: ParseLenient}
+
+	tests := []struct {
+		input      string
+		r, g, b, a float64
+	}{
+		{"0xff8000", 1, 128.0 / 255, 0, 1},
+		{"0x80ff0000", 1, 0, 0, 128.0 / 255},
+		{"255,0,0", 1, 0, 0, 1},
+		{"0, 128, 255", 0, 128.0 / 255, 1, 1},
+		{"255 0 0", 1, 0, 0, 1},
+		{"255, 0, 0, 0.5", 1, 0, 0, 0.5},
+		{"red", 1, 0, 0, 1},
+		{"hsv(0, 100%, 100%)", 1, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		c, err := ParseColorWithOptions(tt.input, lenient)
+		if err != nil {
+			t.Errorf("lenient ParseColorWithOptions(%q) error: %v", tt.input, err)
+			continue
+		}
+		r, g, b, a := c.RGBA()
+		if !floatEqual(r, tt.r) || !floatEqual(g, tt.g) || !floatEqual(b, tt.b) || !floatEqual(a, tt.a) {
+			t.Errorf("lenient ParseColorWithOptions(%q) = (%v, %v, %v, %v), want (%v, %v, %v, %v)",
+				tt.input, r, g, b, a, tt.r, tt.g, tt.b, tt.a)
+		}
+	}
+
+	for _, s := range []string{"0xff00", "0xgg0000", "255,0,300"} {
+		if _, err := ParseColorWithOptions(s, lenient); err == nil {
+			t.Errorf("lenient ParseColorWithOptions(%q) should fail", s)
+		}
+	}
+	if _, err := ParseColor("255,0,0"); err == nil {
+		t.Error("ParseColor should not accept bare channel lists")
+	}
+}
+
+func TestParseHexAlphaFirst(t *testing.T) {
+	opts := ParseOptions{HexAlphaFirst: true}
+
+	c, err := ParseColorWithOptions("#80ff0000", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, g, b, a := c.RGBA()
+	if r != 1 || g != 0 || b != 0 || !floatEqual(a, 128.0/255) {
+		t.Errorf("#80ff0000 = (%v, %v, %v, %v), want red at 50%%", r, g, b, a)
+	}
+
+	c, _ = ParseColorWithOptions("#8f00", opts)
+	if r, _, _, a := c.RGBA(); r != 1 || !floatEqual(a, 0x88/255.0) {
+		t.Errorf("#8f00 = %v", c)
+	}
+
+	// 6-digit hex is unaffected, and strict mode keeps the CSS order
+	c, _ = ParseColorWithOptions("#00ff00", opts)
+	if _, g, _, _ := c.RGBA(); g != 1 {
+		t.Errorf("#00ff00 = %v", c)
+	}
+	c, _ = ParseColorWithOptions("#ff000080", ParseOptions{Mode: ParseStrict, HexAlphaFirst: true})
+	if r, _, _, _ := c.RGBA(); r != 1 {
+		t.Errorf("strict #ff000080 = %v", c)
+	}
+}
+
+func TestParseNameDictionaries(t *testing.T) {
+	brand := NewDictionary("brand", map[string]Color{
+		"Brand Blue": RGB(0.1, 0.3, 0.9),
+		"red":        RGB(0.9, 0.1, 0.1),
+	})
+
+	opts := ParseOptions{Names: []*Dictionary{brand, CSSNames}}
+	c, err := ParseColorWithOptions("brand  blue", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, _, _ := c.RGBA(); r != 0.1 {
+		t.Errorf("brand blue = %v", c)
+	}
+
+	// Earlier dictionaries win
+	c, _ = ParseColorWithOptions("red", opts)
+	if r, _, _, _ := c.RGBA(); r != 0.9 {
+		t.Errorf("red = %v, want the brand red", c)
+	}
+	if _, err := ParseColorWithOptions("navy", opts); err != nil {
+		t.Errorf("navy should fall back to CSS names: %v", err)
+	}
+
+	// Names can be turned off entirely
+	_, err = ParseColorWithOptions("navy", ParseOptions{Names: []*Dictionary{}})
+	if !errors.Is(err, ErrUnknownName) {
+		t.Errorf("navy without dictionaries: error = %v, want ErrUnknownName", err)
+	}
+}
+
+func TestDictionaryLookupCopies(t *testing.T) {
+	c, _ := CSSNames.Lookup("red")
+	c.(*RGBA).G = 1
+	again, _ := CSSNames.Lookup("RED")
+	if _, g, _, _ := again.RGBA(); g != 0 {
+		t.Error("Lookup should return a copy")
+	}
+	if CSSNames.Len() != len(CSSNames.Names()) || CSSNames.Name() != "css" {
+		t.Error("unexpected CSSNames metadata")
+	}
+}
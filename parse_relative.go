@@ -0,0 +1,247 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// relativeChannel describes one channel keyword of a relative color function.
+type relativeChannel struct {
+	keyword    string
+	percentRef float64 // Value that 100% resolves to (0 for hues, where percentages are invalid)
+}
+
+// relativeModel describes a color function that accepts CSS relative color syntax.
+// Channel values use the same units as the function's own arguments in CSS,
+// e.g. 0-255 for rgb() and 0-100 for the saturation and lightness of hsl().
+type relativeModel struct {
+	channels []relativeChannel
+	from     func(c Color) []float64                     // Channel values of the origin color
+	build    func(values []float64, alpha float64) Color // Color from resolved channel values
+}
+
+// relativeModels maps function names to their relative color definitions.
+var relativeModels = map[string]relativeModel{
+	"rgb": {
+		channels: []relativeChannel{{"r", 255}, {"g", 255}, {"b", 255}},
+		from: func(c Color) []float64 {
+			r, g, b, _ := UnclampedRGBA(c)
+			return []float64{r * 255, g * 255, b * 255}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewRGBA(v[0]/255, v[1]/255, v[2]/255, alpha)
+		},
+	},
+	"hsl": {
+		channels: []relativeChannel{{"h", 0}, {"s", 100}, {"l", 100}},
+		from: func(c Color) []float64 {
+			hsl := ToHSL(c)
+			return []float64{hsl.H, hsl.S * 100, hsl.L * 100}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewHSL(v[0], v[1]/100, v[2]/100, alpha)
+		},
+	},
+	"hwb": {
+		channels: []relativeChannel{{"h", 0}, {"w", 100}, {"b", 100}},
+		from: func(c Color) []float64 {
+			hwb := ToHWB(c)
+			return []float64{hwb.H, hwb.W * 100, hwb.B * 100}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewHWB(v[0], v[1]/100, v[2]/100, alpha)
+		},
+	},
+	"lab": {
+		channels: []relativeChannel{{"l", 100}, {"a", 125}, {"b", 125}},
+		from: func(c Color) []float64 {
+			lab := ToLAB(c)
+			return []float64{lab.L, lab.A, lab.B}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewLAB(v[0], v[1], v[2], alpha)
+		},
+	},
+	"lch": {
+		channels: []relativeChannel{{"l", 100}, {"c", 150}, {"h", 0}},
+		from: func(c Color) []float64 {
+			lch := ToLCH(c)
+			return []float64{lch.L, lch.C, lch.H}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewLCH(v[0], v[1], v[2], alpha)
+		},
+	},
+	"oklab": {
+		channels: []relativeChannel{{"l", 1}, {"a", 0.4}, {"b", 0.4}},
+		from: func(c Color) []float64 {
+			lab := ToOKLAB(c)
+			return []float64{lab.L, lab.A, lab.B}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewOKLAB(v[0], v[1], v[2], alpha)
+		},
+	},
+	"oklch": {
+		channels: []relativeChannel{{"l", 1}, {"c", 0.4}, {"h", 0}},
+		from: func(c Color) []float64 {
+			lch := ToOKLCH(c)
+			return []float64{lch.L, lch.C, lch.H}
+		},
+		build: func(v []float64, alpha float64) Color {
+			return NewOKLCH(v[0], v[1], v[2], alpha)
+		},
+	},
+}
+
+// colorFunctionModel returns the relative color definition for a color() space,
+// e.g. color(from red display-p3 r g b) or color(from red xyz x y z).
+func colorFunctionModel(spaceName string) (relativeModel, bool) {
+	if spaceName == "xyz-d50" {
+		return relativeModel{
+			channels: []relativeChannel{{"x", 1}, {"y", 1}, {"z", 1}},
+			from: func(c Color) []float64 {
+				xyz := ToXYZ(c)
+				x, y, z := AdaptD65ToD50(xyz.X, xyz.Y, xyz.Z)
+				return []float64{x, y, z}
+			},
+			build: func(v []float64, alpha float64) Color {
+				return xyzFromD50(NewXYZ(v[0], v[1], v[2], alpha))
+			},
+		}, true
+	}
+	if strings.HasPrefix(spaceName, "xyz") {
+		return relativeModel{
+			channels: []relativeChannel{{"x", 1}, {"y", 1}, {"z", 1}},
+			from: func(c Color) []float64 {
+				xyz := ToXYZ(c)
+				return []float64{xyz.X, xyz.Y, xyz.Z}
+			},
+			build: func(v []float64, alpha float64) Color {
+				return NewXYZ(v[0], v[1], v[2], alpha)
+			},
+		}, true
+	}
+
+	rgbSpace := getRGBColorSpace(spaceName)
+	space := getSpaceByName(spaceName)
+	if space == nil {
+		return relativeModel{}, false
+	}
+	if rgbSpace == nil {
+		return spaceModel(space), true
+	}
+	return relativeModel{
+		channels: []relativeChannel{{"r", 1}, {"g", 1}, {"b", 1}},
+		from: func(c Color) []float64 {
+			xyz := ToXYZ(c)
+			return space.FromXYZ(xyz.X, xyz.Y, xyz.Z)
+		},
+		build: func(v []float64, alpha float64) Color {
+			return newRGBColorSpaceColor(rgbSpace, v[0], v[1], v[2], alpha)
+		},
+	}, true
+}
+
+// parseRelativeColor parses CSS Color 5 relative color syntax, where the channels of an
+// origin color are available as keywords inside the new color's arguments:
+//   oklch(from #3366ff calc(l + 0.1) c h)
+//   rgb(from red r g b / 50%)
+//   hsl(from teal h s calc(l * 0.8))
+//   color(from red display-p3 r g b)
+//
+// args holds the split arguments starting with "from"; the alpha expression, if any, is last.
+// If alpha is omitted, the origin's alpha is kept. Any value may be none.
+func parseRelativeColor(funcName string, args []string, opts ParseOptions) (Color, error) {
+	if len(args) < 2 {
+		return nil, newParseError(ErrSyntax, "", "relative color requires an origin color")
+	}
+
+	origin, err := parseColor(args[1], opts)
+	if err != nil {
+		return nil, argError(err, 1)
+	}
+	exprs := args[2:]
+	first := 2 // index of exprs[0] in args
+
+	var model relativeModel
+	var ok bool
+	switch funcName {
+	case "color":
+		if len(exprs) == 0 {
+			return nil, newParseError(ErrArgCount, "", "color() requires a color space name")
+		}
+		if opts.Mode == ParseStrict && !cssPredefinedSpaces[exprs[0]] {
+			return nil, newArgError(ErrUnknownColorSpace, args, first, fmt.Sprintf("%s is not a CSS predefined color space", exprs[0]))
+		}
+		model, ok = colorFunctionModel(exprs[0])
+		exprs = exprs[1:]
+		first++
+	case "rgba":
+		model, ok = relativeModels["rgb"]
+	case "hsla":
+		model, ok = relativeModels["hsl"]
+	default:
+		model, ok = relativeModels[funcName]
+	}
+	if !ok {
+		return nil, newParseError(ErrSyntax, "", fmt.Sprintf("relative color syntax not supported for %s()", funcName))
+	}
+
+	if len(exprs) != len(model.channels) && len(exprs) != len(model.channels)+1 {
+		return nil, newParseError(ErrArgCount, "", fmt.Sprintf("relative color requires %d channel values", len(model.channels)))
+	}
+
+	// Channel keywords resolve to the origin's values
+	keywords := map[string]float64{"alpha": origin.Alpha()}
+	for i, v := range model.from(origin) {
+		keywords[model.channels[i].keyword] = v
+	}
+
+	values := make([]float64, len(model.channels))
+	for i, ch := range model.channels {
+		if exprs[i] == "none" {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i], err = evalCalc(exprs[i], calcContext{keywords: keywords, percentRef: ch.percentRef})
+		if err != nil {
+			return nil, argError(err, first+i)
+		}
+	}
+
+	alpha := origin.Alpha()
+	if len(exprs) > len(model.channels) {
+		if expr := exprs[len(model.channels)]; expr == "none" {
+			alpha = math.NaN()
+		} else if alpha, err = evalCalc(expr, calcContext{keywords: keywords, percentRef: 1}); err != nil {
+			return nil, argError(err, first+len(model.channels))
+		}
+	}
+
+	return model.build(values, alpha), nil
+}
+
+// spaceModel builds a relative color model for any registered Space, using
+// its lowercased channel names as keywords.
+func spaceModel(space Space) relativeModel {
+	names := space.ChannelNames()
+	channels := make([]relativeChannel, len(names))
+	for i, name := range names {
+		channels[i] = relativeChannel{strings.ToLower(name), 1}
+	}
+	return relativeModel{
+		channels: channels,
+		from: func(c Color) []float64 {
+			xyz := ToXYZ(c)
+			return space.FromXYZ(xyz.X, xyz.Y, xyz.Z)
+		},
+		build: func(v []float64, alpha float64) Color {
+			for i := range v {
+				v[i] = resolveNone(v[i])
+			}
+			return NewSpaceColor(space, v, resolveNone(alpha))
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseRelativeColorIdentity(t *testing.T) {
+	origin, _ := ParseColor("#3366ff")
+
+	inputs := []string{
+		"rgb(from #3366ff r g b)",
+		"rgba(from #3366ff r g b)",
+		"hsl(from #3366ff h s l)",
+		"hwb(from #3366ff h w b)",
+		"lab(from #3366ff l a b)",
+		"lch(from #3366ff l c h)",
+		"oklab(from #3366ff l a b)",
+		"oklch(from #3366ff l c h)",
+		"color(from #3366ff srgb r g b)",
+		"color(from #3366ff display-p3 r g b)",
+		"color(from #3366ff xyz x y z)",
+	}
+
+	for _, input := range inputs {
+		c, err := ParseColor(input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", input, err)
+			continue
+		}
+		if d := DeltaEOK(origin, c); d > 0.001 {
+			t.Errorf("ParseColor(%q) differs from origin by %f", input, d)
+		}
+	}
+}
+
+func TestParseRelativeColorCalc(t *testing.T) {
+	tests := []struct {
+		input   string
+		l, c, h float64
+	}{
+		{"oklch(from oklch(0.5 0.1 200) calc(l + 0.1) c h)", 0.6, 0.1, 200},
+		{"oklch(from oklch(0.5 0.1 200) l calc(c * 2) calc(h - 20))", 0.5, 0.2, 180},
+		{"oklch(from oklch(0.5 0.1 200) l c calc(h + 90deg))", 0.5, 0.1, 290},
+		{"oklch(from oklch(0.5 0.1 200) 80% c h)", 0.8, 0.1, 200},
+		{"oklch(from oklch(0.5 0.1 200) l 25% h)", 0.5, 0.1, 200},
+		{"oklch(from oklch(0.5 0.1 200) min(l, 0.3) max(c, 0.15) h)", 0.3, 0.15, 200},
+		{"oklch(from oklch(0.5 0.1 200) clamp(0.6, l, 0.9) c h)", 0.6, 0.1, 200},
+		{"oklch(from oklch(0.5 0.1 200) calc((l + 0.1) / 2) c h)", 0.3, 0.1, 200},
+		{"oklch(from oklch(0.5 0.1 200) 0.7 0.05 30)", 0.7, 0.05, 30},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		got, ok := c.(*OKLCH)
+		if !ok {
+			t.Errorf("ParseColor(%q) = %T, want *OKLCH", tt.input, c)
+			continue
+		}
+		if abs(got.L-tt.l) > 1e-6 || abs(got.C-tt.c) > 1e-6 || abs(got.H-tt.h) > 1e-6 {
+			t.Errorf("ParseColor(%q) = oklch(%f %f %f), want oklch(%f %f %f)",
+				tt.input, got.L, got.C, got.H, tt.l, tt.c, tt.h)
+		}
+	}
+}
+
+func TestParseRelativeColorAlpha(t *testing.T) {
+	tests := []struct {
+		input string
+		alpha float64
+	}{
+		{"rgb(from red r g b / 50%)", 0.5},
+		{"rgb(from red r g b / 0.25)", 0.25},
+		{"rgb(from rgb(255 0 0 / 0.4) r g b)", 0.4},
+		{"rgb(from rgb(255 0 0 / 0.4) r g b / calc(alpha / 2))", 0.2},
+		{"oklch(from red l c h / alpha)", 1},
+		{"rgb(from red r g b / none)", 0},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		if abs(c.Alpha()-tt.alpha) > 1e-9 {
+			t.Errorf("ParseColor(%q) alpha = %f, want %f", tt.input, c.Alpha(), tt.alpha)
+		}
+	}
+
+	// none is a missing alpha, which carries forward when mixing
+	c, err := ParseColor("rgb(from red r g b / none)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if rgba := c.(*RGBA); !math.IsNaN(rgba.A) {
+		t.Errorf("alpha = %f, want missing", rgba.A)
+	}
+}
+
+func TestParseRelativeColorChannels(t *testing.T) {
+	// rgb() keywords are in 0-255
+	c, err := ParseColor("rgb(from rgb(200 100 50) calc(r / 2) g 0)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	r, g, b, _ := c.RGBA()
+	if abs(r-100.0/255) > 1e-9 || abs(g-100.0/255) > 1e-9 || b != 0 {
+		t.Errorf("Got rgb(%f %f %f)", r*255, g*255, b*255)
+	}
+
+	// hsl() saturation and lightness keywords are in 0-100
+	c, err = ParseColor("hsl(from hsl(120 50% 40%) calc(h + 120) s calc(l + 10))")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	hsl := c.(*HSL)
+	if abs(hsl.H-240) > 1e-6 || abs(hsl.S-0.5) > 1e-6 || abs(hsl.L-0.5) > 1e-6 {
+		t.Errorf("Got hsl(%f %f %f), want hsl(240 0.5 0.5)", hsl.H, hsl.S, hsl.L)
+	}
+
+	// Nested relative colors
+	c, err = ParseColor("oklch(from oklch(from #3366ff l 0 h) l c h)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if c.(*OKLCH).C != 0 {
+		t.Errorf("Nested relative color chroma = %f, want 0", c.(*OKLCH).C)
+	}
+}
+
+func TestParseRelativeColorErrors(t *testing.T) {
+	invalid := []string{
+		"oklch(from)",                           // Missing origin
+		"oklch(from notacolor l c h)",           // Bad origin
+		"oklch(from red l c)",                   // Too few channels
+		"oklch(from red l c h x y)",             // Too many channels
+		"oklch(from red calc(l + ) c h)",        // Incomplete expression
+		"oklch(from red calc(l + 0.1 c h)",      // Unbalanced parentheses
+		"oklch(from red q c h)",                 // Unknown keyword
+		"oklch(from red l c 50%)",               // Percentage hue
+		"oklch(from red calc(l / 0) c h)",       // Division by zero
+		"rgb(from red r g b / calc(alpha * x))", // Unknown keyword in alpha
+		"hsv(from red h s v)",                   // Not a CSS function
+		"color(from red)",                       // Missing color space
+		"color(from red unknown r g b)",         // Unknown color space
+	}
+
+	for _, input := range invalid {
+		if _, err := ParseColor(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestParseNumberCalc(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"calc(255 / 2)", 127.5},
+		{"calc(1 + 2 * 3)", 7},
+		{"calc((1 + 2) * 3)", 9},
+		{"calc(-0.5 + 1)", 0.5},
+		{"calc(10 - -5)", 15},
+		{"calc(50%)", 0.5},
+		{"min(0.2, 0.5, 0.1)", 0.1},
+		{"max(0.2, 0.5, 0.1)", 0.5},
+		{"clamp(0, 1.5, 1)", 1},
+		{"clamp(0, calc(1 - 2), 1)", 0},
+		{"calc(1e2 / 4)", 25},
+		{"calc(180deg / 2)", 90},
+	}
+
+	for _, tt := range tests {
+		got, err := parseNumber(tt.input)
+		if err != nil {
+			t.Errorf("parseNumber(%q) error: %v", tt.input, err)
+			continue
+		}
+		if abs(got-tt.want) > 1e-9 {
+			t.Errorf("parseNumber(%q) = %f, want %f", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorWithCalc(t *testing.T) {
+	c, err := ParseColor("rgb(calc(255 / 2) 0 calc(255 - 55) / calc(1 / 4))")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	r, g, b, a := c.RGBA()
+	if abs(r-0.5) > 1e-9 || g != 0 || abs(b-200.0/255) > 1e-9 || a != 0.25 {
+		t.Errorf("Got rgba(%f %f %f %f)", r, g, b, a)
+	}
+}
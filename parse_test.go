@@ -213,3 +213,95 @@ func TestParseColorRoundTrip(t *testing.T) {
 	}
 }
 
+
+func TestParseHueUnits(t *testing.T) {
+	tests := []struct {
+		input string
+		hue   float64
+	}{
+		{"oklch(0.7 0.1 90)", 90},
+		{"oklch(0.7 0.1 90deg)", 90},
+		{"oklch(0.7 0.1 1.5707963rad)", 90},
+		{"oklch(0.7 0.1 100grad)", 90},
+		{"oklch(0.7 0.1 0.25turn)", 90},
+		{"oklch(0.7 0.1 -0.25turn)", 270},
+		{"oklch(0.7 0.1 calc(0.25turn + 10deg))", 100},
+		{"lch(70 50 0.5turn)", 180},
+		{"hsl(0.5turn 50% 50%)", 180},
+		{"hwb(200grad 10% 10%)", 180},
+
+		// Hues outside 0-360 wrap around, as in CSS
+		{"hsl(1.5turn 100% 50%)", 180},
+		{"hsl(-90 100% 50%)", 270},
+		{"hsl(400, 50%, 50%)", 40},
+		{"hsl(-3.1415926536rad 100% 50%)", 180},
+		{"hsl(500grad 100% 50%)", 90},
+		{"hwb(-0.25turn 10% 10%)", 270},
+		{"hwb(720 10% 10%)", 0},
+		{"lch(70 50 -90)", 270},
+		{"lch(70 50 2turn)", 0},
+		{"oklch(0.7 0.1 -450grad)", 315},
+		{"oklch(0.7 0.1 7rad)", 41.0705},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		var h float64
+		switch v := c.(type) {
+		case *OKLCH:
+			h = v.H
+		case *LCH:
+			h = v.H
+		case *HSL:
+			h = v.H
+		case *HWB:
+			h = v.H
+		}
+		if abs(h-tt.hue) > 1e-4 {
+			t.Errorf("ParseColor(%q) hue = %f, want %f", tt.input, h, tt.hue)
+		}
+	}
+
+	if _, err := ParseColor("oklch(0.7 0.1 90furlong)"); err == nil {
+		t.Error("Expected error for unknown angle unit")
+	}
+}
+
+func TestParsePercentageChannels(t *testing.T) {
+	tests := []struct {
+		input   string
+		l, x, y float64
+	}{
+		{"oklch(50% 50% 120)", 0.5, 0.2, 120},
+		{"oklch(100% 100% 0)", 1, 0.4, 0},
+		{"oklab(40% 100% -50%)", 0.4, 0.4, -0.2},
+		{"lab(50% 100% -40%)", 50, 125, -50},
+		{"lch(50% 100% 30)", 50, 150, 30},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		var l, x, y float64
+		switch v := c.(type) {
+		case *OKLCH:
+			l, x, y = v.L, v.C, v.H
+		case *OKLAB:
+			l, x, y = v.L, v.A, v.B
+		case *LAB:
+			l, x, y = v.L, v.A, v.B
+		case *LCH:
+			l, x, y = v.L, v.C, v.H
+		}
+		if abs(l-tt.l) > 1e-9 || abs(x-tt.x) > 1e-9 || abs(y-tt.y) > 1e-9 {
+			t.Errorf("ParseColor(%q) = (%f, %f, %f), want (%f, %f, %f)", tt.input, l, x, y, tt.l, tt.x, tt.y)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package color
+
+import "testing"
+
+func TestPQAndHLGFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"PQEOTF(1)", PQEOTF(1), 10000},
+		{"PQInverseEOTF(100)", PQInverseEOTF(100), 0.50807},
+		{"PQInverseEOTF(1000)", PQInverseEOTF(1000), 0.75183},
+		{"PQEOTF(PQInverseEOTF(203))", PQEOTF(PQInverseEOTF(203)), 203},
+		{"HLGOETF(1/12)", HLGOETF(1.0 / 12), 0.5},
+		{"HLGOETF(1)", HLGOETF(1), 1},
+		{"HLGInverseOETF(0.75)", HLGInverseOETF(0.75), 0.26496},
+		{"HLGSystemGamma(1000)", HLGSystemGamma(1000), 1.2},
+		{"HLGSystemGamma(2000)", HLGSystemGamma(2000), 1.32643},
+	}
+	for _, tt := range tests {
+		if !floatNear(tt.got, tt.want, 1e-4*tt.want) {
+			t.Errorf("%s = %.6f, want %.6f", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRec2100Spaces(t *testing.T) {
+	white := NewSpaceColor(SRGBSpace, []float64{1, 1, 1}, 1)
+	tests := []struct {
+		space Space
+		want  float64
+	}{
+		{Rec2100PQSpace, PQInverseEOTF(SDRWhiteLuminance)},
+		{Rec2100HLGSpace, 0.75},
+		{Rec2100LinearSpace, 1},
+	}
+	for _, tt := range tests {
+		// The sRGB and Rec. 2020 matrices agree to about 1e-4
+		got := white.ConvertTo(tt.space).Channels()
+		for _, v := range got {
+			if !floatNear(v, tt.want, 1e-3) {
+				t.Errorf("%s white = %v, want %.6f", tt.space.Name(), got, tt.want)
+				break
+			}
+		}
+
+		// Round trip through XYZ
+		for _, ch := range [][]float64{{0.1, 0.5, 0.9}, {0.8, 0.2, 0.05}, {0, 0, 0}} {
+			x, y, z := tt.space.ToXYZ(ch)
+			back := tt.space.FromXYZ(x, y, z)
+			for i := range ch {
+				if !floatNear(back[i], ch[i], 1e-6) {
+					t.Errorf("%s round trip of %v = %v", tt.space.Name(), ch, back)
+					break
+				}
+			}
+		}
+
+		meta := Metadata(tt.space)
+		if meta == nil || !meta.IsHDR {
+			t.Errorf("%s metadata = %+v, want IsHDR", tt.space.Name(), meta)
+		}
+		if space, ok := GetSpace(tt.space.Name()); !ok || space != tt.space {
+			t.Errorf("%s is not registered", tt.space.Name())
+		}
+	}
+}
+
+func TestRec2100HDRGamut(t *testing.T) {
+	// A highlight four times brighter than SDR white (812 cd/m²) fits every
+	// Rec. 2100 space, but not sRGB
+	bright := NewSpaceColor(Rec2100LinearSpace, []float64{4, 4, 4}, 1)
+	for _, space := range []Space{Rec2100LinearSpace, Rec2100PQSpace, Rec2100HLGSpace} {
+		if !InGamutOf(bright, space) {
+			t.Errorf("linear 4.0 is out of the %s gamut", space.Name())
+		}
+	}
+	if InGamut(bright) {
+		t.Error("linear 4.0 is in the sRGB gamut")
+	}
+	if InGamutOf(NewSpaceColor(Rec2100LinearSpace, []float64{60, 0, 0}, 1), Rec2100LinearSpace) {
+		t.Error("linear 60 (above 10,000 cd/m²) is in gamut")
+	}
+
+	// Reference white shows at 203 cd/m² on a 1000 cd/m² HLG display
+	_, y, _ := NewRec2100HLGSpace(1000, 0).ToXYZ([]float64{0.75, 0.75, 0.75})
+	if !floatNear(y, 1, 1e-5) {
+		t.Errorf("HLG 0.75 Y = %g, want 1", y)
+	}
+	// A brighter display raises the system gamma, darkening mid-tones relative to white
+	_, y1000, _ := Rec2100HLGSpace.ToXYZ([]float64{0.5, 0.5, 0.5})
+	_, y2000, _ := NewRec2100HLGSpace(2000, 0).ToXYZ([]float64{0.5, 0.5, 0.5})
+	if y2000 >= y1000 {
+		t.Errorf("HLG 0.5 Y at 2000 cd/m² = %g, want less than %g", y2000, y1000)
+	}
+}
@@ -13,6 +13,25 @@ var spaceRegistry = struct {
 	spaces: make(map[string]Space),
 }
 
+// FunctionParser parses the arguments of a color function such as "cmyk(...)".
+// args are the lowercased arguments, split on commas or whitespace, with an
+// alpha value after "/" appended last: "cmyk(0% 50% 100% 0% / 0.5)" gives
+// ["0%", "50%", "100%", "0%", "0.5"]. ParseChannel and ParseAngle parse
+// individual arguments. opts are the options of the ParseColorWithOptions call.
+type FunctionParser func(args []string, opts ParseOptions) (Color, error)
+
+// builtinFunctions are the parsers of the built-in color functions, set in init.
+// ParseStrict uses them directly, so RegisterFunction cannot change what it accepts.
+var builtinFunctions map[string]FunctionParser
+
+// functionRegistry holds the parsers for color functions, by lowercase name
+var functionRegistry = struct {
+	sync.RWMutex
+	parsers map[string]FunctionParser
+}{
+	parsers: make(map[string]FunctionParser),
+}
+
 func init() {
 	// Register all built-in color spaces with their primary names and aliases
 	RegisterSpace("srgb", SRGBSpace)
@@ -22,7 +41,7 @@ func init() {
 	RegisterSpace("display-p3-d65", DisplayP3Space) // Alias
 
 	RegisterSpace("dci-p3", DCIP3Space)
-	RegisterSpace("dci-p3-d65", DCIP3Space) // Alias
+	RegisterSpace("dci-p3-d65", dciP3D65Space)
 
 	RegisterSpace("a98-rgb", A98RGBSpace)
 	RegisterSpace("a98rgb", A98RGBSpace) // Alias
@@ -37,8 +56,31 @@ func init() {
 	RegisterSpace("rec709", Rec709Space)
 	RegisterSpace("rec-709", Rec709Space) // Alias
 
+	RegisterSpace("rec2100-pq", Rec2100PQSpace)
+	RegisterSpace("rec2100-hlg", Rec2100HLGSpace)
+	RegisterSpace("rec2100-linear", Rec2100LinearSpace)
+
+	// ACES color spaces for VFX and grading
+	RegisterSpace("aces2065-1", ACES2065Space)
+	RegisterSpace("aces", ACES2065Space) // Alias
+	RegisterSpace("acescg", ACEScgSpace)
+	RegisterSpace("acescc", ACESccSpace)
+	RegisterSpace("acescct", ACEScctSpace)
+
 	RegisterSpace("oklch", OKLCHSpace)
 
+	RegisterSpace("cam16-ucs", CAM16UCSSpace)
+	RegisterSpace("cam16ucs", CAM16UCSSpace) // Alias
+
+	RegisterSpace("hct", HCTSpace)
+
+	RegisterSpace("jzazbz", JzazbzSpace)
+	RegisterSpace("jzczhz", JzCzhzSpace)
+
+	RegisterSpace("ictcp", ICtCpSpace)
+	RegisterSpace("ictcp-pq", ICtCpSpace) // Alias
+	RegisterSpace("ictcp-hlg", ICtCpHLGSpace)
+
 	// LOG color spaces for professional cinema cameras
 	RegisterSpace("c-log", CLogSpace)
 	RegisterSpace("clog", CLogSpace) // Alias
@@ -57,6 +99,47 @@ func init() {
 
 	RegisterSpace("bmd-film", BMDFilmSpace)
 	RegisterSpace("bmdfilm", BMDFilmSpace) // Alias
+
+	RegisterSpace("arri-logc4", ArriLogC4Space)
+	RegisterSpace("logc4", ArriLogC4Space) // Alias
+
+	RegisterSpace("canon-log2", CLog2Space)
+	RegisterSpace("clog2", CLog2Space) // Alias
+
+	RegisterSpace("canon-log3", CLog3Space)
+	RegisterSpace("clog3", CLog3Space) // Alias
+
+	RegisterSpace("s-log3-cine", SLog3CineSpace)
+	RegisterSpace("slog3-cine", SLog3CineSpace) // Alias
+
+	RegisterSpace("davinci-intermediate", DaVinciIntermediateSpace)
+	RegisterSpace("davinci-wg", DaVinciIntermediateSpace) // Alias
+
+	RegisterSpace("apple-log", AppleLogSpace)
+	RegisterSpace("applelog", AppleLogSpace) // Alias
+
+	RegisterSpace("f-log2", FLog2Space)
+	RegisterSpace("flog2", FLog2Space) // Alias
+
+	// Register the built-in color functions
+	builtinFunctions = map[string]FunctionParser{
+		"rgb":   func(args []string, opts ParseOptions) (Color, error) { return parseRGB(args, false, opts) },
+		"rgba":  func(args []string, opts ParseOptions) (Color, error) { return parseRGB(args, true, opts) },
+		"hsl":   func(args []string, opts ParseOptions) (Color, error) { return parseHSL(args, false, opts) },
+		"hsla":  func(args []string, opts ParseOptions) (Color, error) { return parseHSL(args, true, opts) },
+		"hwb":   parseHWB,
+		"hsv":   func(args []string, _ ParseOptions) (Color, error) { return parseHSV(args, false) },
+		"hsva":  func(args []string, _ ParseOptions) (Color, error) { return parseHSV(args, true) },
+		"lab":   parseLAB,
+		"lch":   parseLCH,
+		"oklab": func(args []string, _ ParseOptions) (Color, error) { return parseOKLAB(args) },
+		"oklch": func(args []string, _ ParseOptions) (Color, error) { return parseOKLCH(args) },
+		"xyz":   func(args []string, _ ParseOptions) (Color, error) { return parseXYZ(args) },
+		"color": parseColorFunction,
+	}
+	for name, parser := range builtinFunctions {
+		RegisterFunction(name, parser)
+	}
 }
 
 // RegisterSpace registers a color space with the given name(s).
@@ -113,3 +196,53 @@ func UnregisterSpace(name string) {
 	defer spaceRegistry.Unlock()
 	delete(spaceRegistry.spaces, strings.ToLower(name))
 }
+
+// RegisterFunction registers a parser for a color function, so that ParseColor
+// accepts "name(...)". The name is case-insensitive; registering an existing
+// name (including a built-in such as "rgb") replaces its parser.
+// Custom functions are not CSS, so ParseStrict mode rejects them, and always
+// parses the CSS functions with the built-in parsers.
+//
+// Example:
+//   RegisterFunction("gray", func(args []string, _ ParseOptions) (Color, error) {
+//       v, err := ParseChannel(args[0], 1)
+//       if err != nil {
+//           return nil, err
+//       }
+//       return RGB(v, v, v), nil
+//   })
+//   c, _ := ParseColor("gray(50%)")
+func RegisterFunction(name string, parser FunctionParser) {
+	functionRegistry.Lock()
+	defer functionRegistry.Unlock()
+	functionRegistry.parsers[strings.ToLower(name)] = parser
+}
+
+// GetFunctionParser retrieves the parser registered for a color function.
+// The name lookup is case-insensitive.
+func GetFunctionParser(name string) (FunctionParser, bool) {
+	functionRegistry.RLock()
+	defer functionRegistry.RUnlock()
+	parser, ok := functionRegistry.parsers[strings.ToLower(name)]
+	return parser, ok
+}
+
+// ListFunctions returns the names of all registered color functions.
+// The names are returned in no particular order.
+func ListFunctions() []string {
+	functionRegistry.RLock()
+	defer functionRegistry.RUnlock()
+
+	names := make([]string, 0, len(functionRegistry.parsers))
+	for name := range functionRegistry.parsers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// UnregisterFunction removes a color function from the registry.
+func UnregisterFunction(name string) {
+	functionRegistry.Lock()
+	defer functionRegistry.Unlock()
+	delete(functionRegistry.parsers, strings.ToLower(name))
+}
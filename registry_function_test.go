@@ -0,0 +1,141 @@
+package color
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterFunction(t *testing.T) {
+	RegisterFunction("device-cmyk", func(args []string, _ ParseOptions) (Color, error) {
+		if len(args) < 4 {
+			return nil, errors.New("device-cmyk requires 4 arguments")
+		}
+		var v [4]float64
+		for i := range v {
+			var err error
+			if v[i], err = ParseChannel(args[i], 1); err != nil {
+				return nil, err
+			}
+		}
+		k := v[3]
+		return RGB((1-v[0])*(1-k), (1-v[1])*(1-k), (1-v[2])*(1-k)), nil
+	})
+	defer UnregisterFunction("device-cmyk")
+
+	c, err := ParseColor("device-cmyk(0% 100% 100% 0%)")
+	if err != nil {
+		t.Fatalf("ParseColor error: %v", err)
+	}
+	if r, g, b, _ := c.RGBA(); r != 1 || g != 0 || b != 0 {
+		t.Errorf("device-cmyk red = (%v, %v, %v)", r, g, b)
+	}
+
+	found := false
+	for _, name := range ListFunctions() {
+		found = found || name == "device-cmyk"
+	}
+	if !found {
+		t.Error("ListFunctions should include device-cmyk")
+	}
+
+	// Custom functions are not CSS
+	_, err = ParseColorWithOptions("device-cmyk(0 1 1 0)", ParseOptions{Mode: ParseStrict})
+	if !errors.Is(err, ErrUnknownFunction) {
+		t.Errorf("strict mode error = %v, want ErrUnknownFunction", err)
+	}
+
+	UnregisterFunction("device-cmyk")
+	if _, err := ParseColor("device-cmyk(0 1 1 0)"); !errors.Is(err, ErrUnknownFunction) {
+		t.Errorf("after UnregisterFunction error = %v, want ErrUnknownFunction", err)
+	}
+}
+
+func TestRegisterFunctionOverridesBuiltin(t *testing.T) {
+	builtin, _ := GetFunctionParser("hsv")
+	defer RegisterFunction("hsv", builtin)
+
+	RegisterFunction("HSV", func(args []string, opts ParseOptions) (Color, error) {
+		return RGB(0, 0, 1), nil
+	})
+	c, err := ParseColor("hsv(0, 100%, 100%)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, b, _ := c.RGBA(); b != 1 {
+		t.Errorf("overridden hsv() = %v", c)
+	}
+
+	// Strict mode always parses CSS functions with the built-in parser
+	builtinRGB, _ := GetFunctionParser("rgb")
+	defer RegisterFunction("rgb", builtinRGB)
+	RegisterFunction("rgb", func(args []string, opts ParseOptions) (Color, error) {
+		return RGB(0, 0, 1), nil
+	})
+	c, err = ParseColorWithOptions("rgb(255 0 0)", ParseOptions{Mode: ParseStrict})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, b, _ := c.RGBA(); r != 1 || b != 0 {
+		t.Errorf("strict rgb(255 0 0) = %v, want red", c)
+	}
+}
+
+func TestParseAngle(t *testing.T) {
+	deg, err := ParseAngle("0.25turn")
+	if err != nil || deg != 90 {
+		t.Errorf("ParseAngle(0.25turn) = %v, %v", deg, err)
+	}
+}
+
+func TestColorFunctionRegisteredSpaces(t *testing.T) {
+	tests := []struct {
+		input string
+		space Space
+	}{
+		{"color(s-log3 0.41 0.39 0.35)", SLog3Space},
+		{"color(arri-logc 0.5 0.5 0.5 / 0.5)", ArriLogCSpace},
+		{"color(rec709 1 0 0)", Rec709Space},
+		{"color(oklch 0.7 0.15 240)", OKLCHSpace},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		sc, ok := c.(SpaceColor)
+		if !ok || sc.Space() != tt.space {
+			t.Errorf("ParseColor(%q) = %T, want a %s SpaceColor", tt.input, c, tt.space.Name())
+		}
+	}
+
+	c, _ := ParseColor("color(arri-logc 0.5 0.5 0.5 / 0.5)")
+	if c.Alpha() != 0.5 {
+		t.Errorf("alpha = %v, want 0.5", c.Alpha())
+	}
+
+	// Relative syntax uses the space's channel names
+	rel, err := ParseColor("color(from red s-log3 r g b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xyz := ToXYZ(RGB(1, 0, 0))
+	want := SLog3Space.FromXYZ(xyz.X, xyz.Y, xyz.Z)
+	got := rel.(SpaceColor).Channels()
+	for i := range want {
+		if !floatEqual(got[i], want[i]) {
+			t.Errorf("relative s-log3 red = %v, want %v", got, want)
+			break
+		}
+	}
+
+	_, err = ParseColor("color(s-log3 0.4 0.4)")
+	if !errors.Is(err, ErrArgCount) {
+		t.Errorf("too few channels: error = %v, want ErrArgCount", err)
+	}
+	_, err = ParseColor("color(s-log3 0.4 x 0.4)")
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Channel != "g" {
+		t.Errorf("bad channel: error = %v", err)
+	}
+}
@@ -60,18 +60,12 @@ func (cs *RGBColorSpace) ConvertRGBToXYZ(r, g, b, a float64) *XYZ {
 
 // sRGB transfer function (same as gammaCorrection)
 func sRGBTransfer(linear float64) float64 {
-	if linear <= 0.0031308 {
-		return 12.92 * linear
-	}
-	return 1.055*math.Pow(linear, 1.0/2.4) - 0.055
+	return gammaCorrection(linear)
 }
 
 // sRGB inverse transfer function (same as inverseGammaCorrection)
 func sRGBInverseTransfer(encoded float64) float64 {
-	if encoded <= 0.04045 {
-		return encoded / 12.92
-	}
-	return math.Pow((encoded+0.055)/1.055, 2.4)
+	return inverseGammaCorrection(encoded)
 }
 
 // Linear transfer function (no encoding)
@@ -104,116 +98,28 @@ func gammaInverseTransferFunc(gamma float64) func(float64) float64 {
 	}
 }
 
-// Define color spaces
+// Define color spaces. Their matrices are those of the registered spaces,
+// relative to each space's own white.
 var (
-	// sRGB color space (already supported, but defined here for consistency)
-	sRGBSpace = &RGBColorSpace{
-		Name: "srgb",
-		// D65 white point, sRGB primaries
-		XYZToRGBMatrix: [9]float64{
-			3.2404542, -1.5371385, -0.4985314,
-			-0.9692660, 1.8760108, 0.0415560,
-			0.0556434, -0.2040259, 1.0572252,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.4124564, 0.3575761, 0.1804375,
-			0.2126729, 0.7151522, 0.0721750,
-			0.0193339, 0.1191920, 0.9503041,
-		},
-		TransferFunc:        sRGBTransfer,
-		InverseTransferFunc: sRGBInverseTransfer,
-	}
-
-	// sRGB-linear: linear sRGB (no gamma encoding)
-	sRGBLinearSpace = &RGBColorSpace{
-		Name: "srgb-linear",
-		// Same matrices as sRGB, but linear transfer
-		XYZToRGBMatrix: [9]float64{
-			3.2404542, -1.5371385, -0.4985314,
-			-0.9692660, 1.8760108, 0.0415560,
-			0.0556434, -0.2040259, 1.0572252,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.4124564, 0.3575761, 0.1804375,
-			0.2126729, 0.7151522, 0.0721750,
-			0.0193339, 0.1191920, 0.9503041,
-		},
-		TransferFunc:        linearTransfer,
-		InverseTransferFunc: linearInverseTransfer,
-	}
-
-	// Display P3: Wide gamut RGB (D65 white point)
-	displayP3Space = &RGBColorSpace{
-		Name: "display-p3",
-		// D65 white point, Display P3 primaries
-		XYZToRGBMatrix: [9]float64{
-			2.493496911941425, -0.9313836179191239, -0.40271078445071684,
-			-0.8294889695615747, 1.7626640603183463, 0.023624685841943577,
-			0.03584583024378447, -0.07617238926804182, 0.9568845240076872,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.4865709486482162, 0.26566769316909306, 0.1982172852343625,
-			0.2289745640697488, 0.6917385218365064, 0.079286914093745,
-			0.000000000000000, 0.04511338185890264, 1.043944368900976,
-		},
-		TransferFunc:        sRGBTransfer, // Display P3 uses sRGB transfer function
-		InverseTransferFunc: sRGBInverseTransfer,
-	}
-
-	// Adobe RGB 1998: D65 white point
-	a98RGBSpace = &RGBColorSpace{
-		Name: "a98-rgb",
-		// D65 white point, Adobe RGB 1998 primaries
-		XYZToRGBMatrix: [9]float64{
-			1.9624274, -0.6105343, -0.3413404,
-			-0.9787684, 1.9161415, 0.0334540,
-			0.0286869, -0.1406752, 1.3487655,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.5766690429101305, 0.1855582379065463, 0.1882286462349947,
-			0.29734497525053605, 0.6273635662554661, 0.07529145849399788,
-			0.02703136138641234, 0.07068885253582723, 0.9913375368376388,
-		},
-		TransferFunc:        gammaTransferFunc(2.2), // Adobe RGB uses gamma 2.2
-		InverseTransferFunc: gammaInverseTransferFunc(2.2),
-	}
-
-	// ProPhoto RGB: D50 white point
-	proPhotoRGBSpace = &RGBColorSpace{
-		Name: "prophoto-rgb",
-		// D50 white point, ProPhoto RGB primaries
-		XYZToRGBMatrix: [9]float64{
-			1.3459433, -0.2556075, -0.0511118,
-			-0.5445989, 1.5081673, 0.0205351,
-			0.0000000, 0.0000000, 1.2118128,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.7976749, 0.1351917, 0.0313534,
-			0.2880402, 0.7118741, 0.0000857,
-			0.0000000, 0.0000000, 0.8252100,
-		},
-		TransferFunc:        gammaTransferFunc(1.8), // ProPhoto RGB uses gamma 1.8
-		InverseTransferFunc: gammaInverseTransferFunc(1.8),
-	}
+	sRGBSpace        = rgbColorSpaceOf(SRGBSpace, "srgb")
+	sRGBLinearSpace  = rgbColorSpaceOf(SRGBLinearSpace, "srgb-linear")
+	displayP3Space   = rgbColorSpaceOf(DisplayP3Space, "display-p3")
+	a98RGBSpace      = rgbColorSpaceOf(A98RGBSpace, "a98-rgb")
+	proPhotoRGBSpace = rgbColorSpaceOf(ProPhotoRGBSpace, "prophoto-rgb")
+	rec2020Space     = rgbColorSpaceOf(Rec2020Space, "rec2020")
+)
 
-	// Rec. 2020: Wide gamut for UHDTV (D65 white point)
-	rec2020Space = &RGBColorSpace{
-		Name: "rec2020",
-		// D65 white point, Rec. 2020 primaries
-		XYZToRGBMatrix: [9]float64{
-			1.7166511, -0.3556708, -0.2533663,
-			-0.6666844, 1.6164812, 0.0157685,
-			0.0176399, -0.0427706, 0.9421031,
-		},
-		RGBToXYZMatrix: [9]float64{
-			0.6369580483012914, 0.14461690358620832, 0.1688809751641721,
-			0.262704531669281, 0.6779980715188708, 0.05930171646986196,
-			0.000000000000000, 0.028072693049087428, 1.060985057710791,
-		},
-		TransferFunc:        rec2020Transfer, // Rec. 2020 uses a different transfer function
-		InverseTransferFunc: rec2020InverseTransfer,
+// rgbColorSpaceOf describes an RGB Space as an RGBColorSpace named name.
+func rgbColorSpaceOf(space Space, name string) *RGBColorSpace {
+	s := space.(*rgbSpace)
+	return &RGBColorSpace{
+		Name:                name,
+		XYZToRGBMatrix:      s.xyzToRGBMatrix,
+		RGBToXYZMatrix:      s.rgbToXYZMatrix,
+		TransferFunc:        s.transferFunc,
+		InverseTransferFunc: s.inverseTransferFunc,
 	}
-)
+}
 
 // Rec. 2020 transfer function (PQ-like, but simplified to gamma 2.4 for compatibility)
 func rec2020Transfer(linear float64) float64 {
@@ -72,3 +72,92 @@ func TestSRGBLinear(t *testing.T) {
 	}
 }
 
+func TestDCIP3Matrix(t *testing.T) {
+	// DCI-P3 RGB to XYZ relative to the DCI white, from SMPTE RP 431-2
+	want := [9]float64{
+		0.4451698156, 0.2771344092, 0.1722826698,
+		0.2094916779, 0.7215952542, 0.0689130679,
+		0.0000000000, 0.0470605601, 0.9073553944,
+	}
+	got := DCIP3Space.(*rgbSpace).rgbToXYZMatrix
+	for i := range want {
+		if !floatNear(got[i], want[i], 1e-6) {
+			t.Fatalf("DCI-P3 to XYZ = %v, want %v", got, want)
+		}
+	}
+
+	// The DCI white is adapted to D65
+	x, y, z := DCIP3Space.ToXYZ([]float64{1, 1, 1})
+	if !floatNear(x, whiteD65[0], 1e-6) || !floatNear(y, 1, 1e-6) || !floatNear(z, whiteD65[2], 1e-6) {
+		t.Errorf("DCI-P3 white = %g, %g, %g, want D65", x, y, z)
+	}
+}
+
+
+func TestParseColorFunctionByteChannels(t *testing.T) {
+	// Integers above 1 are read as 0-255, as ParseColor always has
+	c, err := ParseColor("color(srgb 255 128 0)")
+	if err != nil {
+		t.Fatalf("ParseColor failed: %v", err)
+	}
+	r, g, b, _ := c.RGBA()
+	if abs(r-1) > 1e-9 || abs(g-128.0/255) > 1e-9 || b != 0 {
+		t.Errorf("color(srgb 255 128 0) = RGB(%v, %v, %v)", r, g, b)
+	}
+
+	// Decimals are out-of-gamut 0-1 values
+	c, _ = ParseColor("color(srgb 1.2 -0.1 0)")
+	if r, g, _, _ := UnclampedRGBA(c); abs(r-1.2) > 1e-5 || abs(g+0.1) > 1e-5 {
+		t.Errorf("color(srgb 1.2 -0.1 0) = RGB(%v, %v)", r, g)
+	}
+
+	// CSS has no 0-255 color(), so strict parsing keeps the values
+	c, _ = ParseColorWithOptions("color(srgb 255 128 0)", ParseOptions{Mode: ParseStrict})
+	if r, _, _, _ := UnclampedRGBA(c); abs(r-255) > 1e-3 {
+		t.Errorf("strict color(srgb 255 128 0) red = %v, want 255", r)
+	}
+}
+
+func TestParseXYZD50(t *testing.T) {
+	// The D50 white is adapted to the D65 white
+	c, err := ParseColor("color(xyz-d50 0.96422 1 0.82521)")
+	if err != nil {
+		t.Fatalf("ParseColor failed: %v", err)
+	}
+	xyz := c.(*XYZ)
+	if abs(xyz.X-0.95047) > 1e-4 || abs(xyz.Y-1) > 1e-4 || abs(xyz.Z-1.08883) > 1e-4 {
+		t.Errorf("D50 white = %v, want D65 white", xyz)
+	}
+
+	// Formatting as xyz-d65 and parsing back keeps the color
+	back, _ := ParseColor(Format(c, DefaultFormatOptions))
+	if d := DeltaEOK(c, back); d > 1e-4 {
+		t.Errorf("xyz-d50 round trip differs by %f", d)
+	}
+
+	// Relative color syntax reads and writes D50 channels
+	rel, _ := ParseColor("color(from color(xyz-d50 0.2 0.3 0.4) xyz-d50 x y z)")
+	orig, _ := ParseColor("color(xyz-d50 0.2 0.3 0.4)")
+	if d := DeltaEOK(rel, orig); d > 1e-6 {
+		t.Errorf("relative xyz-d50 differs from origin by %f", d)
+	}
+}
+
+func TestSRGBMatricesShared(t *testing.T) {
+	// The RGBA conversions and InGamut use the matrices of SRGBSpace
+	for _, rgb := range [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.2, 0.5, 0.9}} {
+		lr, lg, lb := inverseGammaCorrection(rgb[0]), inverseGammaCorrection(rgb[1]), inverseGammaCorrection(rgb[2])
+		x, y, z := linearSRGBToXYZ(lr, lg, lb)
+		wx, wy, wz := SRGBSpace.ToXYZ(rgb)
+		if x != wx || y != wy || z != wz {
+			t.Errorf("linearSRGBToXYZ(%v) = %g, %g, %g, SRGBSpace gives %g, %g, %g", rgb, x, y, z, wx, wy, wz)
+		}
+	}
+
+	// A color on the SRGBSpace boundary is in gamut by both
+	green := NewSpaceColor(DisplayP3Space, []float64{0, 1, 0}, 1)
+	clipped := MapToGamutOf(green, SRGBSpace, GamutClip)
+	if !InGamut(clipped) || !InGamutOf(clipped, SRGBSpace) {
+		t.Errorf("Clipped P3 green %v is out of gamut", clipped.Channels())
+	}
+}
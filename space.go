@@ -104,25 +104,9 @@ func (c *spaceColor) ConvertTo(target Space) SpaceColor {
 
 // ConvertToWithMapping converts this color to a different color space with gamut mapping.
 // Use this when you need control over how out-of-gamut colors are handled.
+// Colors are mapped into the gamut of the target space itself (see MapToGamutOf).
 func (c *spaceColor) ConvertToWithMapping(target Space, mapping GamutMapping) SpaceColor {
-	// First convert normally
-	converted := c.ConvertTo(target)
-
-	// Check if it's an RGB space and apply gamut mapping if needed
-	if target.Name() == "sRGB" || target.Name() == "sRGB-linear" ||
-		target.Name() == "display-p3" || target.Name() == "a98-rgb" ||
-		target.Name() == "prophoto-rgb" || target.Name() == "rec2020" {
-
-		// Convert to Color and apply gamut mapping
-		mappedColor := MapToGamut(converted, mapping)
-
-		// Convert back to SpaceColor in the target space
-		xyz := ToXYZ(mappedColor)
-		targetChannels := target.FromXYZ(xyz.X, xyz.Y, xyz.Z)
-		return NewSpaceColor(target, targetChannels, c.alpha)
-	}
-
-	return converted
+	return MapToGamutOf(c, target, mapping)
 }
 
 // RGBA implements Color (converts to sRGB RGBA)
@@ -131,21 +115,23 @@ func (c *spaceColor) RGBA() (r, g, b, a float64) {
 	return rgba.R, rgba.G, rgba.B, rgba.A
 }
 
+// linearRGBA implements linearColor (unclamped linear sRGB)
+func (c *spaceColor) linearRGBA() (r, g, b, a float64) {
+	x, y, z := c.space.ToXYZ(c.values)
+	r, g, b = xyzToLinearSRGB(x, y, z)
+	return r, g, b, c.alpha
+}
+
 // ToRGBA implements SpaceColor (explicit conversion to sRGB)
 func (c *spaceColor) ToRGBA() *RGBA {
-	// Convert through XYZ to sRGB
-	x, y, z := c.space.ToXYZ(c.values)
-	
-	// Convert XYZ to linear sRGB
-	linearR := x*3.2404542 - y*1.5371385 - z*0.4985314
-	linearG := -x*0.9692660 + y*1.8760108 + z*0.0415560
-	linearB := x*0.0556434 - y*0.2040259 + z*1.0572252
-	
+	// Convert through XYZ to linear sRGB
+	linearR, linearG, linearB, _ := c.linearRGBA()
+
 	// Apply sRGB gamma correction (using function from xyz.go)
 	r := sRGBTransfer(linearR)
 	g := sRGBTransfer(linearG)
 	b := sRGBTransfer(linearB)
-	
+
 	return NewRGBA(
 		clamp01(r),
 		clamp01(g),
@@ -153,4 +139,3 @@ func (c *spaceColor) ToRGBA() *RGBA {
 		c.alpha,
 	)
 }
-
@@ -0,0 +1,94 @@
+package color
+
+import "math"
+
+// ACES (Academy Color Encoding System) color spaces.
+// All of them use the ACES white point and hold scene-linear light up to the
+// half-float maximum of 65504, where 0.18 is mid gray and 1.0 diffuse white.
+// Colors are adapted to and from D65 XYZ with the Bradford transform.
+
+// ACES primaries as xy chromaticities
+var (
+	// AP0 encloses the whole spectral locus, for archiving and interchange
+	acesAP0 = [3][2]float64{{0.7347, 0.2653}, {0.0000, 1.0000}, {0.0001, -0.0770}}
+
+	// AP1 is slightly larger than Rec. 2020, for rendering and grading
+	acesAP1 = [3][2]float64{{0.713, 0.293}, {0.165, 0.830}, {0.128, 0.044}}
+)
+
+// acesHalfMax is the largest value of a half float, the ACES range limit.
+const acesHalfMax = 65504.0
+
+var (
+	// ACES2065Space represents ACES2065-1: linear AP0, the ACES interchange and archival encoding.
+	ACES2065Space Space = newPrimariesSpace("aces2065-1", acesAP0, WhiteACES, linearTransfer, linearInverseTransfer).withRange(0, acesHalfMax)
+
+	// ACEScgSpace represents ACEScg: linear AP1, the working space for CGI and compositing.
+	ACEScgSpace Space = newPrimariesSpace("acescg", acesAP1, WhiteACES, linearTransfer, linearInverseTransfer).withRange(0, acesHalfMax)
+
+	// ACESccSpace represents ACEScc: AP1 with a pure logarithmic encoding, for grading.
+	// Mid gray encodes to 0.4136; the encoding runs from -0.3584 (black) to 1.468.
+	ACESccSpace Space = newPrimariesSpace("acescc", acesAP1, WhiteACES, acesCCTransfer, acesCCInverseTransfer).withRange(acesCCTransfer(0), acesCCTransfer(acesHalfMax))
+
+	// ACEScctSpace represents ACEScct: ACEScc with a linear toe, which grades
+	// shadows more like traditional film LOG encodings.
+	ACEScctSpace Space = newPrimariesSpace("acescct", acesAP1, WhiteACES, acesCCTTransfer, acesCCTInverseTransfer).withRange(0, acesCCTTransfer(acesHalfMax))
+)
+
+// acesAP0ToXYZ and acesAP1ToXYZ are the linear AP0 and AP1 to XYZ matrices, relative to the ACES white.
+var (
+	acesAP0ToXYZ = ACES2065Space.(*rgbSpace).rgbToXYZMatrix
+	acesAP1ToXYZ = ACEScgSpace.(*rgbSpace).rgbToXYZMatrix
+)
+
+// ACEScc Transfer Functions
+// Reference: Academy S-2014-003
+
+func acesCCTransfer(linear float64) float64 {
+	switch {
+	case linear <= 0:
+		return (-16 + 9.72) / 17.52 // log2(2^-16)
+	case linear < math.Pow(2, -15):
+		return (math.Log2(math.Pow(2, -16)+linear*0.5) + 9.72) / 17.52
+	default:
+		return (math.Log2(linear) + 9.72) / 17.52
+	}
+}
+
+func acesCCInverseTransfer(encoded float64) float64 {
+	switch {
+	case encoded < (9.72-15)/17.52:
+		return (math.Pow(2, encoded*17.52-9.72) - math.Pow(2, -16)) * 2
+	case encoded < (math.Log2(acesHalfMax)+9.72)/17.52:
+		return math.Pow(2, encoded*17.52-9.72)
+	default:
+		return acesHalfMax
+	}
+}
+
+// ACEScct Transfer Functions
+// Reference: Academy S-2016-001
+
+const (
+	acesCCTXBreak = 0.0078125
+	acesCCTYBreak = 0.155251141552511
+	acesCCTA      = 10.5402377416545
+	acesCCTB      = 0.0729055341958355
+)
+
+func acesCCTTransfer(linear float64) float64 {
+	if linear <= acesCCTXBreak {
+		return acesCCTA*linear + acesCCTB
+	}
+	return (math.Log2(linear) + 9.72) / 17.52
+}
+
+func acesCCTInverseTransfer(encoded float64) float64 {
+	if encoded <= acesCCTYBreak {
+		return (encoded - acesCCTB) / acesCCTA
+	}
+	if encoded < (math.Log2(acesHalfMax)+9.72)/17.52 {
+		return math.Pow(2, encoded*17.52-9.72)
+	}
+	return acesHalfMax
+}
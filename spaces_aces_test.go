@@ -0,0 +1,116 @@
+package color
+
+import "testing"
+
+func TestACESMatrices(t *testing.T) {
+	// AP0 to AP1, from the ACES reference implementation
+	want := [9]float64{
+		1.4514393161, -0.2365107469, -0.2149285693,
+		-0.0765537734, 1.1762296998, -0.0996759264,
+		0.0083161484, -0.0060324498, 0.9977163014,
+	}
+	got := mulMatrices3(invert3(acesAP1ToXYZ), acesAP0ToXYZ)
+	for i := range want {
+		if !floatNear(got[i], want[i], 1e-6) {
+			t.Fatalf("AP0 to AP1 = %v, want %v", got, want)
+		}
+	}
+
+	// AP0 to XYZ, from SMPTE ST 2065-1
+	ap0 := [9]float64{
+		0.9525523959, 0.0000000000, 0.0000936786,
+		0.3439664498, 0.7281660966, -0.0721325464,
+		0.0000000000, 0.0000000000, 1.0088251844,
+	}
+	for i := range ap0 {
+		if !floatNear(acesAP0ToXYZ[i], ap0[i], 1e-6) {
+			t.Fatalf("AP0 to XYZ = %v, want %v", acesAP0ToXYZ, ap0)
+		}
+	}
+}
+
+func TestACESConversions(t *testing.T) {
+	// ACES white is the adopted white: it maps to D65 white
+	for _, space := range []Space{ACES2065Space, ACEScgSpace} {
+		x, y, z := space.ToXYZ([]float64{1, 1, 1})
+		if !floatNear(x, whiteD65[0], 1e-5) || !floatNear(y, 1, 1e-5) || !floatNear(z, whiteD65[2], 1e-5) {
+			t.Errorf("%s white = %g, %g, %g, want D65", space.Name(), x, y, z)
+		}
+	}
+
+	// Linear sRGB red in ACEScg (Bradford adaptation)
+	red := NewSpaceColor(SRGBLinearSpace, []float64{1, 0, 0}, 1).ConvertTo(ACEScgSpace).Channels()
+	for i, v := range []float64{0.613097, 0.070194, 0.020616} {
+		if !floatNear(red[i], v, 1e-3) {
+			t.Errorf("sRGB red in ACEScg = %v", red)
+			break
+		}
+	}
+
+	// Between ACES spaces, colors stay in the ACES white
+	ap0 := NewSpaceColor(ACES2065Space, []float64{1, 0, 0}, 1).ConvertTo(ACEScgSpace).Channels()
+	for i, v := range []float64{1.4514393161, -0.0765537734, 0.0083161484} {
+		if !floatNear(ap0[i], v, 1e-5) {
+			t.Errorf("AP0 red in ACEScg = %v", ap0)
+			break
+		}
+	}
+}
+
+func TestACESLogEncodings(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"ACEScc(0.18)", acesCCTransfer(0.18), 0.4135884},
+		{"ACEScc(1)", acesCCTransfer(1), 0.5547945},
+		{"ACEScc(0)", acesCCTransfer(0), -0.3584475},
+		{"ACEScct(0.18)", acesCCTTransfer(0.18), 0.4135884},
+		{"ACEScct(0)", acesCCTTransfer(0), 0.0729055},
+		{"ACEScct(break)", acesCCTTransfer(acesCCTXBreak), acesCCTYBreak},
+	}
+	for _, tt := range tests {
+		if !floatNear(tt.got, tt.want, 1e-6) {
+			t.Errorf("%s = %.7f, want %.7f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	for _, space := range []Space{ACESccSpace, ACEScctSpace} {
+		for _, v := range []float64{0, 1e-5, 0.005, 0.18, 1, 16, 200} {
+			x, y, z := ACEScgSpace.ToXYZ([]float64{v, v / 2, v / 4})
+			got := space.FromXYZ(x, y, z)
+			back := ACEScgSpace.FromXYZ(space.ToXYZ(got))
+			for i, want := range []float64{v, v / 2, v / 4} {
+				if !floatNear(back[i], want, 1e-9+1e-6*want) {
+					t.Errorf("%s round trip of %g = %v", space.Name(), v, back)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestACESGamut(t *testing.T) {
+	// Scene-linear highlights and black are inside the ACES range
+	for _, space := range []Space{ACES2065Space, ACEScgSpace, ACESccSpace, ACEScctSpace} {
+		for _, v := range []float64{0, 0.18, 100} {
+			c := NewSpaceColor(ACEScgSpace, []float64{v, v, v}, 1)
+			if !InGamutOf(c, space) {
+				t.Errorf("ACEScg %g is out of the %s gamut", v, space.Name())
+			}
+		}
+		if meta := Metadata(space); meta == nil || meta.WhitePoint != "ACES" || !meta.IsHDR {
+			t.Errorf("%s metadata = %+v", space.Name(), meta)
+		}
+	}
+
+	// Rec. 2020 green is inside AP1 and AP0, a spectral green only inside AP0
+	green := NewSpaceColor(Rec2020Space, []float64{0, 1, 0}, 1)
+	if !InGamutOf(green, ACEScgSpace) {
+		t.Error("Rec. 2020 green is out of the ACEScg gamut")
+	}
+	spectral := NewXYZ(0.0713, 0.8, 0.0881, 1) // 520 nm (x = 0.0743, y = 0.8338)
+	if InGamutOf(spectral, ACEScgSpace) || !InGamutOf(spectral, ACES2065Space) {
+		t.Error("520 nm should be outside AP1 and inside AP0")
+	}
+}
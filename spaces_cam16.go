@@ -0,0 +1,78 @@
+package color
+
+import "math"
+
+// CAM16UCSSpace is CAM16-UCS (J', a', b') under DefaultViewingConditions,
+// a uniform color space in which Euclidean distance is a color difference.
+var CAM16UCSSpace Space = NewCAM16UCSSpace(DefaultViewingConditions)
+
+// cam16UCSSpace implements Space for CAM16-UCS (Li et al., 2017)
+type cam16UCSSpace struct {
+	p *cam16Params
+}
+
+// NewCAM16UCSSpace creates a CAM16-UCS space for the given viewing conditions.
+//
+// Example:
+//   dim := color.DefaultViewingConditions
+//   dim.Surround = color.SurroundDim
+//   ucs := color.NewCAM16UCSSpace(dim)
+//   jab := color.NewSpaceColor(color.SRGBSpace, []float64{1, 0, 0}, 1).ConvertTo(ucs)
+func NewCAM16UCSSpace(vc ViewingConditions) Space {
+	return &cam16UCSSpace{p: vc.params()}
+}
+
+func (s *cam16UCSSpace) Name() string {
+	return "CAM16-UCS"
+}
+
+func (s *cam16UCSSpace) Channels() int {
+	return 3
+}
+
+func (s *cam16UCSSpace) ChannelNames() []string {
+	return []string{"J", "a", "b"}
+}
+
+func (s *cam16UCSSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("CAM16-UCS space requires 3 channels")
+	}
+
+	jp, ap, bp := channels[0], channels[1], channels[2]
+	j := jp / (1.7 - 0.007*jp)
+	mp := math.Hypot(ap, bp)
+	m := (math.Exp(0.0228*mp) - 1) / 0.0228
+	h := normalizeHue(math.Atan2(bp, ap) * 180 / math.Pi)
+
+	return s.p.toXYZ(j, m/s.p.flRoot, h)
+}
+
+func (s *cam16UCSSpace) FromXYZ(x, y, z float64) []float64 {
+	j, c, h := s.p.fromXYZ(x, y, z)
+	m := c * s.p.flRoot
+
+	jp := 1.7 * j / (1 + 0.007*j)
+	mp := math.Log(1+0.0228*m) / 0.0228
+	hRad := h * math.Pi / 180
+
+	return []float64{jp, mp * math.Cos(hRad), mp * math.Sin(hRad)}
+}
+
+// DeltaECAM16 calculates the color difference as the Euclidean distance in
+// CAM16-UCS under DefaultViewingConditions. Like DeltaE2000, a difference
+// around 1 is just noticeable.
+func DeltaECAM16(c1, c2 Color) float64 {
+	return DeltaECAM16With(c1, c2, DefaultViewingConditions)
+}
+
+// DeltaECAM16With is DeltaECAM16 under the given viewing conditions.
+func DeltaECAM16With(c1, c2 Color, vc ViewingConditions) float64 {
+	space := &cam16UCSSpace{p: vc.params()}
+	xyz1, xyz2 := ToXYZ(c1), ToXYZ(c2)
+	a := space.FromXYZ(xyz1.X, xyz1.Y, xyz1.Z)
+	b := space.FromXYZ(xyz2.X, xyz2.Y, xyz2.Z)
+
+	dj, da, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt(dj*dj + da*da + db*db)
+}
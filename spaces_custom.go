@@ -0,0 +1,103 @@
+package color
+
+import (
+	"errors"
+	"math"
+)
+
+// TransferFunction pairs the encoding of an RGB space's channels with its inverse.
+type TransferFunction struct {
+	// Encode converts linear light to an encoded channel value.
+	Encode func(linear float64) float64
+
+	// Decode converts an encoded channel value back to linear light.
+	Decode func(encoded float64) float64
+}
+
+// Common transfer functions for NewRGBSpace
+var (
+	// TransferLinear leaves channels linear.
+	TransferLinear = TransferFunction{Encode: linearTransfer, Decode: linearInverseTransfer}
+
+	// TransferSRGB is the sRGB piecewise curve, also used by Display P3.
+	TransferSRGB = TransferFunction{Encode: sRGBTransfer, Decode: sRGBInverseTransfer}
+
+	// TransferRec709 is the Rec. 709 curve.
+	TransferRec709 = TransferFunction{Encode: rec709Transfer, Decode: rec709InverseTransfer}
+)
+
+// TransferGamma returns a pure power-law transfer function, as reported by
+// a display's EDID or measured by a calibration tool.
+func TransferGamma(gamma float64) TransferFunction {
+	return TransferFunction{Encode: gammaTransferFunc(gamma), Decode: gammaInverseTransferFunc(gamma)}
+}
+
+// NewRGBSpace creates an RGB color space from the xy chromaticities of its red,
+// green and blue primaries, its white point and a transfer function. The
+// conversion matrices are derived so that RGB (1, 1, 1) is the white exactly.
+// If the white is not D65, colors are adapted to and from D65 XYZ with the
+// given adaptation; the built-in spaces, such as ProPhotoRGBSpace, use
+// complete Bradford adaptation. A white of D65 skips the adaptation.
+//
+// Primaries may lie outside the spectral locus, as camera gamuts and ACES AP0
+// do, but none may have y = 0. It returns an error if a primary has y = 0, the
+// white's Y is not above 0, the primaries do not form a triangle, or the degree
+// of adaptation is not between 0 and 1. A degree of 0, which includes the zero
+// ChromaticAdaptation, is an error unless the white is D65: the space would
+// read its white-relative XYZ as D65 XYZ and shift every color.
+// The space is not registered; call RegisterSpace to look it up by name.
+//
+// Example:
+//   // A display profile from its EDID chromaticities
+//   monitor, err := color.NewRGBSpace("my-monitor",
+//       [3][2]float64{{0.6797, 0.3096}, {0.2656, 0.6611}, {0.1504, 0.0596}},
+//       color.NewWhitePoint("native", 0.3134, 0.3291),
+//       color.TransferGamma(2.2),
+//       color.ChromaticAdaptation{Method: color.CATBradford, Degree: 1})
+func NewRGBSpace(name string, primaries [3][2]float64, white WhitePoint, transfer TransferFunction, adaptation ChromaticAdaptation) (Space, error) {
+	for _, p := range primaries {
+		if p[1] == 0 || math.IsNaN(p[1]) {
+			return nil, errors.New("color: RGB primaries must have y != 0")
+		}
+	}
+	if !(white.Y > 0) {
+		return nil, errors.New("color: white point must have Y > 0")
+	}
+	if !(adaptation.Degree >= 0 && adaptation.Degree <= 1) {
+		return nil, errors.New("color: degree of adaptation must be between 0 and 1")
+	}
+	if adaptation.Degree == 0 && white.xyz() != whiteD65 {
+		return nil, errors.New("color: degree of adaptation must be above 0 for a white point other than D65")
+	}
+	s := newPrimariesSpace(name, primaries, white, transfer.Encode, transfer.Decode)
+	s.adaptation = adaptation
+	for _, v := range s.xyzToRGBMatrix {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("color: RGB primaries must not be collinear")
+		}
+	}
+	return s, nil
+}
+
+// newPrimariesSpace creates an RGB space whose matrices are derived from its
+// primaries and white, adapted with bradfordAdaptation. Every built-in RGB
+// space is created with it.
+func newPrimariesSpace(name string, primaries [3][2]float64, white WhitePoint, transfer, inverse func(float64) float64) *rgbSpace {
+	m := rgbToXYZFromPrimaries(primaries[0], primaries[1], primaries[2], white.xyz())
+	return &rgbSpace{
+		name:                name,
+		xyzToRGBMatrix:      invert3(m),
+		rgbToXYZMatrix:      m,
+		transferFunc:        transfer,
+		inverseTransferFunc: inverse,
+		whitePoint:          white,
+		adaptation:          bradfordAdaptation,
+	}
+}
+
+// withRange sets the encoded range of an RGB space whose channels do not
+// run from 0 to 1, and returns the space.
+func (s *rgbSpace) withRange(encodedMin, encodedMax float64) *rgbSpace {
+	s.encodedMin, s.encodedMax = encodedMin, encodedMax
+	return s
+}
@@ -0,0 +1,119 @@
+package color
+
+import "testing"
+
+func TestNewRGBSpace(t *testing.T) {
+	custom, err := NewRGBSpace("custom-srgb", srgbPrimaries, NewWhitePoint("D65", 0.3127, 0.3290), TransferSRGB, bradfordAdaptation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range [][]float64{{1, 0, 0}, {0.2, 0.4, 0.6}, {1, 1, 1}} {
+		got := NewSpaceColor(custom, c, 1).ConvertTo(SRGBSpace).Channels()
+		for i := range c {
+			if !floatNear(got[i], c[i], 1e-4) {
+				t.Errorf("custom sRGB %v in sRGB = %v", c, got)
+				break
+			}
+		}
+	}
+
+	// RGB white is the white point exactly
+	x, y, z := custom.ToXYZ([]float64{1, 1, 1})
+	if !floatNear(x, whiteD65[0], 1e-6) || !floatNear(y, 1, 1e-6) || !floatNear(z, whiteD65[2], 1e-6) {
+		t.Errorf("white = %g, %g, %g, want D65", x, y, z)
+	}
+
+	// A D50 space is adapted like ProPhoto RGB
+	d50, err := NewRGBSpace("custom-prophoto", proPhotoPrimaries, NewWhitePoint("D50", 0.3457, 0.3585), TransferGamma(1.8), bradfordAdaptation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range [][]float64{{1, 0, 0}, {0.3, 0.5, 0.7}} {
+		want := NewSpaceColor(ProPhotoRGBSpace, c, 1).ConvertTo(SRGBLinearSpace).Channels()
+		got := NewSpaceColor(d50, c, 1).ConvertTo(SRGBLinearSpace).Channels()
+		for i := range want {
+			if !floatNear(got[i], want[i], 1e-3) {
+				t.Errorf("custom ProPhoto %v = %v, want %v", c, got, want)
+				break
+			}
+		}
+	}
+	if !InGamutOf(NewSpaceColor(d50, []float64{1, 1, 1}, 1), d50) {
+		t.Error("white is out of its own gamut")
+	}
+}
+
+func TestNewRGBSpaceInvalid(t *testing.T) {
+	invalid := map[string]struct {
+		primaries [3][2]float64
+		white     WhitePoint
+		degree    float64
+	}{
+		"zero y primary":       {[3][2]float64{{0.64, 0.33}, {0.30, 0.60}, {0.15, 0}}, WhiteD65, 1},
+		"negative Y white":     {displayP3Primaries, WhitePoint{X: 0.95, Y: -1, Z: 1.09}, 1},
+		"zero white":           {displayP3Primaries, WhitePoint{}, 1},
+		"collinear primaries":  {[3][2]float64{{0.1, 0.1}, {0.2, 0.2}, {0.3, 0.3}}, WhiteD65, 1},
+		"degree above 1":       {displayP3Primaries, WhiteD50, 2},
+		"no adaptation to D65": {displayP3Primaries, WhiteD50, 0},
+	}
+	for name, tt := range invalid {
+		adaptation := ChromaticAdaptation{Method: CATBradford, Degree: tt.degree}
+		if s, err := NewRGBSpace("bad", tt.primaries, tt.white, TransferLinear, adaptation); err == nil {
+			t.Errorf("%s: NewRGBSpace = %v, want an error", name, s)
+		}
+	}
+
+	// The zero ChromaticAdaptation is fine with a D65 white, which is not adapted
+	if _, err := NewRGBSpace("p3", displayP3Primaries, WhiteD65, TransferSRGB, ChromaticAdaptation{}); err != nil {
+		t.Errorf("D65 without adaptation: %v", err)
+	}
+
+	// Imaginary primaries below the y axis are allowed, as in ACES AP0
+	if _, err := NewRGBSpace("ap0", acesAP0, WhiteACES, TransferLinear, bradfordAdaptation); err != nil {
+		t.Errorf("AP0: %v", err)
+	}
+}
+
+func TestRGBSpaceMatricesConsistent(t *testing.T) {
+	// Both matrices of every RGB space must be inverses, and map white to white
+	for _, name := range ListSpaces() {
+		space, _ := GetSpace(name)
+		s, ok := space.(*rgbSpace)
+		if !ok {
+			continue
+		}
+		id := mulMatrices3(s.xyzToRGBMatrix, s.rgbToXYZMatrix)
+		for i, v := range id {
+			want := 0.0
+			if i%4 == 0 {
+				want = 1
+			}
+			if !floatNear(v, want, 1e-5) {
+				t.Errorf("%s: xyzToRGB × rgbToXYZ = %v", name, id)
+				break
+			}
+		}
+
+		white := s.whitePoint.xyz()
+		got := mulMatrix3(s.rgbToXYZMatrix, 1, 1, 1)
+		for i := range got {
+			if !floatNear(got[i], white[i], 1e-5) {
+				t.Errorf("%s: RGB white = %v, want %v", name, got, white)
+				break
+			}
+		}
+	}
+}
+
+func TestTransferGamma(t *testing.T) {
+	tf := TransferGamma(2.2)
+	for _, v := range []float64{0, 0.18, 0.5, 1} {
+		if got := tf.Decode(tf.Encode(v)); !floatNear(got, v, 1e-12) {
+			t.Errorf("gamma 2.2 round trip of %g = %g", v, got)
+		}
+	}
+	if got := tf.Encode(0.5); !floatNear(got, 0.7297400, 1e-6) {
+		t.Errorf("gamma 2.2 of 0.5 = %g", got)
+	}
+}
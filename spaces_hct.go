@@ -0,0 +1,33 @@
+package color
+
+// HCTSpace represents the HCT color space (CAM16 hue and chroma, L* tone).
+// Unlike NewHCT, conversions through the Space are exact and not limited to
+// sRGB, like OKLCHSpace.
+var HCTSpace Space = &hctSpace{}
+
+// hctSpace implements Space for HCT
+type hctSpace struct{}
+
+func (s *hctSpace) Name() string {
+	return "HCT"
+}
+
+func (s *hctSpace) Channels() int {
+	return 3
+}
+
+func (s *hctSpace) ChannelNames() []string {
+	return []string{"H", "C", "T"}
+}
+
+func (s *hctSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("HCT space requires 3 channels")
+	}
+	return hctToXYZ(channels[0], channels[1], channels[2])
+}
+
+func (s *hctSpace) FromXYZ(x, y, z float64) []float64 {
+	hct := xyzToHCT(x, y, z)
+	return []float64{hct.H, hct.C, hct.T}
+}
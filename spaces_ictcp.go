@@ -0,0 +1,101 @@
+package color
+
+// ICtCpSpace represents ICtCp with the PQ transfer function (ITU-R BT.2100),
+// the space of DeltaEITP. Relative XYZ is placed with Y = 1 at
+// SDRWhiteLuminance; values above 1 are HDR, up to 10,000 cd/m².
+var ICtCpSpace Space = &ictcpSpace{name: "ICtCp", hlg: false}
+
+// ICtCpHLGSpace represents ICtCp with the HLG transfer function (ITU-R BT.2100).
+// Relative XYZ is placed with Y = 1 at HLG reference white (signal 0.75).
+var ICtCpHLGSpace Space = &ictcpSpace{name: "ICtCp-HLG", hlg: true}
+
+// ictcpSpace implements Space for ICtCp
+type ictcpSpace struct {
+	name string
+	hlg  bool
+}
+
+// ictcpLMS converts linear Rec. 2020 RGB to LMS.
+var ictcpLMS = [9]float64{
+	1688.0 / 4096, 2146.0 / 4096, 262.0 / 4096,
+	683.0 / 4096, 2951.0 / 4096, 462.0 / 4096,
+	99.0 / 4096, 309.0 / 4096, 3688.0 / 4096,
+}
+
+// ictcpPQ and ictcpHLG convert encoded L'M'S' to ICtCp.
+var (
+	ictcpPQ = [9]float64{
+		2048.0 / 4096, 2048.0 / 4096, 0,
+		6610.0 / 4096, -13613.0 / 4096, 7003.0 / 4096,
+		17933.0 / 4096, -17390.0 / 4096, -543.0 / 4096,
+	}
+	ictcpHLG = [9]float64{
+		2048.0 / 4096, 2048.0 / 4096, 0,
+		3625.0 / 4096, -7465.0 / 4096, 3840.0 / 4096,
+		9500.0 / 4096, -9212.0 / 4096, -288.0 / 4096,
+	}
+)
+
+var (
+	// ictcpXYZToLMS and ictcpLMSToXYZ combine ictcpLMS with the Rec. 2020 primaries
+	ictcpXYZToLMS = mulMatrices3(ictcpLMS, invert3(rec2020RGBToXYZ))
+	ictcpLMSToXYZ = invert3(ictcpXYZToLMS)
+
+	ictcpPQInv  = invert3(ictcpPQ)
+	ictcpHLGInv = invert3(ictcpHLG)
+)
+
+// rec2020RGBToXYZ is the linear Rec. 2020 to XYZ matrix.
+var rec2020RGBToXYZ = Rec2020Space.(*rgbSpace).rgbToXYZMatrix
+
+func (s *ictcpSpace) Name() string {
+	return s.name
+}
+
+func (s *ictcpSpace) Channels() int {
+	return 3
+}
+
+func (s *ictcpSpace) ChannelNames() []string {
+	return []string{"I", "Ct", "Cp"}
+}
+
+func (s *ictcpSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("ICtCp space requires 3 channels")
+	}
+
+	toLMS := ictcpPQInv
+	if s.hlg {
+		toLMS = ictcpHLGInv
+	}
+	lms := mulMatrix3(toLMS, channels[0], channels[1], channels[2])
+	for i, v := range lms {
+		if s.hlg {
+			lms[i] = hlgDecode(v) / hlgReferenceWhite
+		} else {
+			lms[i] = pqDecode(v) * 10000 / SDRWhiteLuminance
+		}
+	}
+
+	xyz := mulMatrix3(ictcpLMSToXYZ, lms[0], lms[1], lms[2])
+	return xyz[0], xyz[1], xyz[2]
+}
+
+func (s *ictcpSpace) FromXYZ(x, y, z float64) []float64 {
+	lms := mulMatrix3(ictcpXYZToLMS, x, y, z)
+	for i, v := range lms {
+		if s.hlg {
+			lms[i] = hlgEncode(v * hlgReferenceWhite)
+		} else {
+			lms[i] = pqEncode(v * SDRWhiteLuminance / 10000)
+		}
+	}
+
+	toICtCp := ictcpPQ
+	if s.hlg {
+		toICtCp = ictcpHLG
+	}
+	ictcp := mulMatrix3(toICtCp, lms[0], lms[1], lms[2])
+	return ictcp[:]
+}
@@ -0,0 +1,68 @@
+package color
+
+import "math"
+
+// JzazbzSpace represents the Jzazbz color space, with relative XYZ scaled
+// so that Y = 1 is SDRWhiteLuminance.
+var JzazbzSpace Space = &jzazbzSpace{}
+
+// JzCzhzSpace represents JzCzhz, the cylindrical form of Jzazbz.
+var JzCzhzSpace Space = &jzczhzSpace{}
+
+// jzazbzSpace implements Space for Jzazbz
+type jzazbzSpace struct{}
+
+func (s *jzazbzSpace) Name() string {
+	return "Jzazbz"
+}
+
+func (s *jzazbzSpace) Channels() int {
+	return 3
+}
+
+func (s *jzazbzSpace) ChannelNames() []string {
+	return []string{"Jz", "az", "bz"}
+}
+
+func (s *jzazbzSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("Jzazbz space requires 3 channels")
+	}
+	x, y, z = jzazbzToAbsoluteXYZ(channels[0], channels[1], channels[2])
+	return x / SDRWhiteLuminance, y / SDRWhiteLuminance, z / SDRWhiteLuminance
+}
+
+func (s *jzazbzSpace) FromXYZ(x, y, z float64) []float64 {
+	jz, az, bz := absoluteXYZToJzazbz(x*SDRWhiteLuminance, y*SDRWhiteLuminance, z*SDRWhiteLuminance)
+	return []float64{jz, az, bz}
+}
+
+// jzczhzSpace implements Space for JzCzhz
+type jzczhzSpace struct{}
+
+func (s *jzczhzSpace) Name() string {
+	return "JzCzhz"
+}
+
+func (s *jzczhzSpace) Channels() int {
+	return 3
+}
+
+func (s *jzczhzSpace) ChannelNames() []string {
+	return []string{"Jz", "Cz", "hz"}
+}
+
+func (s *jzczhzSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("JzCzhz space requires 3 channels")
+	}
+	rad := channels[2] * math.Pi / 180
+	return JzazbzSpace.ToXYZ([]float64{channels[0], channels[1] * math.Cos(rad), channels[1] * math.Sin(rad)})
+}
+
+func (s *jzczhzSpace) FromXYZ(x, y, z float64) []float64 {
+	jab := JzazbzSpace.FromXYZ(x, y, z)
+	c := math.Sqrt(jab[1]*jab[1] + jab[2]*jab[2])
+	h := normalizeHue(math.Atan2(jab[2], jab[1]) * 180 / math.Pi)
+	return []float64{jab[0], c, h}
+}
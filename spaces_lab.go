@@ -0,0 +1,56 @@
+package color
+
+import "strings"
+
+// labSpace implements Space for CIELAB relative to any white point
+type labSpace struct {
+	name       string
+	white      WhitePoint
+	adaptation ChromaticAdaptation
+}
+
+// NewLABSpace creates a CIELAB space relative to a white point, such as
+// WhiteD50 for ICC profiles and print. Colors are adapted to and from D65
+// XYZ with adaptation, usually complete Bradford adaptation as in ICC
+// profiles. The LAB type is always relative to D65.
+//
+// Example:
+//   bradford := color.ChromaticAdaptation{Method: color.CATBradford, Degree: 1}
+//   labD50 := color.NewLABSpace(color.WhiteD50, bradford)
+//   c := color.NewSpaceColor(color.SRGBSpace, []float64{1, 0, 0}, 1).ConvertTo(labD50)
+//   // c.Channels() ≈ [54.29, 80.80, 69.89]
+func NewLABSpace(white WhitePoint, adaptation ChromaticAdaptation) Space {
+	name := "lab-custom"
+	if white.Name != "" {
+		name = "lab-" + strings.ToLower(white.Name)
+	}
+	return &labSpace{name: name, white: white, adaptation: adaptation}
+}
+
+func (s *labSpace) Name() string {
+	return s.name
+}
+
+func (s *labSpace) Channels() int {
+	return 3
+}
+
+func (s *labSpace) ChannelNames() []string {
+	return []string{"L", "a", "b"}
+}
+
+func (s *labSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("LAB space requires 3 channels")
+	}
+	white := s.white.xyz()
+	x, y, z = labToXYZ(channels[0], channels[1], channels[2], white)
+	return s.adaptation.adapt(x, y, z, white, whiteD65)
+}
+
+func (s *labSpace) FromXYZ(x, y, z float64) []float64 {
+	white := s.white.xyz()
+	x, y, z = s.adaptation.unadapt(x, y, z, white, whiteD65)
+	l, a, b := xyzToLAB(x, y, z, white)
+	return []float64{l, a, b}
+}
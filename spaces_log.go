@@ -34,7 +34,7 @@ func cLogInverseTransfer(encoded float64) float64 {
 
 // Sony S-Log3 Transfer Functions
 // S-Log3 is Sony's logarithmic color space for cinema cameras (newest version)
-// Reference: Sony S-Log3 specification
+// Reference: Sony S-Log3 technical summary (18% gray encodes to 420/1023)
 
 func sLog3Transfer(linear float64) float64 {
 	// S-Log3 transfer function: linear to S-Log3
@@ -42,33 +42,22 @@ func sLog3Transfer(linear float64) float64 {
 		linear = 0
 	}
 
-	// S-Log3 parameters
-	const a = 0.01125000
-	const b = 0.42188671
-	const c = 0.42188671
-	const k = 261.5
-
-	if linear >= a {
-		return (420.0 + math.Log10((linear+0.01)/0.18)*(c*k)) / 1023.0
+	if linear >= 0.01125000 {
+		return (420.0 + math.Log10((linear+0.01)/(0.18+0.01))*261.5) / 1023.0
 	}
 
-	return (linear * (171.2102946929 - 95.0) / 0.01125000 + 95.0) / 1023.0
+	return (linear*(171.2102946929-95.0)/0.01125000 + 95.0) / 1023.0
 }
 
 func sLog3InverseTransfer(encoded float64) float64 {
 	// S-Log3 inverse: S-Log3 to linear
 	encoded = encoded * 1023.0
 
-	const a = 0.01125000
-	const b = 0.42188671
-	const c = 0.42188671
-	const k = 261.5
-
 	if encoded >= 171.2102946929 {
-		return (math.Pow(10, ((encoded-420.0)/(c*k))) * 0.18) - 0.01
+		return math.Pow(10, (encoded-420.0)/261.5)*(0.18+0.01) - 0.01
 	}
 
-	return ((encoded - 95.0) / (171.2102946929 - 95.0) * 0.01125000)
+	return (encoded - 95.0) * 0.01125000 / (171.2102946929 - 95.0)
 }
 
 // Panasonic V-Log Transfer Functions
@@ -219,127 +208,246 @@ func bmdFilmInverseTransfer(encoded float64) float64 {
 	return math.Pow(2, (encoded-logOffset-0.5)/0.07) - linearRange
 }
 
+// ARRI LogC4 Transfer Functions
+// LogC4 is ARRI's logarithmic encoding for the ALEXA 35; unlike LogC3 it does not depend on EI
+// Reference: ARRI LogC4 specification
+
+const (
+	logC4A = (262144.0 - 16) / 117.45 // (2^18 - 16) / 117.45
+	logC4B = (1023.0 - 95) / 1023
+	logC4C = 95.0 / 1023
+)
+
+var (
+	logC4S = 7 * math.Ln2 * math.Pow(2, 7-14*logC4C/logC4B) / (logC4A * logC4B)
+	logC4T = (math.Pow(2, 14*(-logC4C/logC4B)+6) - 64) / logC4A
+)
+
+func arriLogC4Transfer(linear float64) float64 {
+	if linear >= logC4T {
+		return (math.Log2(logC4A*linear+64)-6)/14*logC4B + logC4C
+	}
+	return (linear - logC4T) / logC4S
+}
+
+func arriLogC4InverseTransfer(encoded float64) float64 {
+	if encoded >= 0 {
+		return (math.Pow(2, 14*(encoded-logC4C)/logC4B+6) - 64) / logC4A
+	}
+	return encoded*logC4S + logC4T
+}
+
+// Canon Log 2 and Canon Log 3 Transfer Functions
+// Scene linear is divided by 0.9 (Canon's reflectance scale), and the curve
+// output is placed in the 10-bit legal range, as the cameras record it
+// Reference: Canon Log Gamma Curves white paper, v1.2
+
+// canonLegalRange maps a full-range value to 10-bit legal range, and back.
+func canonLegalRange(v float64) float64 {
+	return v*(940.0-64)/1023 + 64.0/1023
+}
+
+func canonFullRange(v float64) float64 {
+	return (v - 64.0/1023) * 1023 / (940.0 - 64)
+}
+
+func cLog2Transfer(linear float64) float64 {
+	x := linear / 0.9
+	if x < 0 {
+		return canonLegalRange(-0.281863093*math.Log10(-x*87.09937546+1) + 0.035388128)
+	}
+	return canonLegalRange(0.281863093*math.Log10(x*87.09937546+1) + 0.035388128)
+}
+
+func cLog2InverseTransfer(encoded float64) float64 {
+	v := canonFullRange(encoded)
+	if v < 0.035388128 {
+		return -(math.Pow(10, (0.035388128-v)/0.281863093) - 1) / 87.09937546 * 0.9
+	}
+	return (math.Pow(10, (v-0.035388128)/0.281863093) - 1) / 87.09937546 * 0.9
+}
+
+func cLog3Transfer(linear float64) float64 {
+	x := linear / 0.9
+	switch {
+	case x < -0.014:
+		return canonLegalRange(-0.42889912*math.Log10(-x*14.98325+1) + 0.07623209)
+	case x <= 0.014:
+		return canonLegalRange(2.3069815*x + 0.073059361)
+	default:
+		return canonLegalRange(0.42889912*math.Log10(x*14.98325+1) + 0.069886632)
+	}
+}
+
+func cLog3InverseTransfer(encoded float64) float64 {
+	v := canonFullRange(encoded)
+	switch {
+	case v < 0.04076162:
+		return -(math.Pow(10, (0.07623209-v)/0.42889912) - 1) / 14.98325 * 0.9
+	case v <= 0.105357102:
+		return (v - 0.073059361) / 2.3069815 * 0.9
+	default:
+		return (math.Pow(10, (v-0.069886632)/0.42889912) - 1) / 14.98325 * 0.9
+	}
+}
+
+// DaVinci Intermediate Transfer Functions
+// Reference: Blackmagic Design, DaVinci Resolve Wide Gamut Intermediate
+
+const (
+	davinciA      = 0.0075
+	davinciB      = 7.0
+	davinciC      = 0.07329248
+	davinciM      = 10.44426855
+	davinciLinCut = 0.00262409
+	davinciLogCut = 0.02740668
+)
+
+func davinciIntermediateTransfer(linear float64) float64 {
+	if linear <= davinciLinCut {
+		return linear * davinciM
+	}
+	return (math.Log2(linear+davinciA) + davinciB) * davinciC
+}
+
+func davinciIntermediateInverseTransfer(encoded float64) float64 {
+	if encoded <= davinciLogCut {
+		return encoded / davinciM
+	}
+	return math.Pow(2, encoded/davinciC-davinciB) - davinciA
+}
+
+// Apple Log Transfer Functions
+// Reference: Apple Log Profile White Paper
+
+const (
+	appleLogR0    = -0.05641088
+	appleLogRt    = 0.01
+	appleLogC     = 47.28711236
+	appleLogBeta  = 0.00964052
+	appleLogGamma = 0.08550479
+	appleLogDelta = 0.69336945
+)
+
+func appleLogTransfer(linear float64) float64 {
+	switch {
+	case linear >= appleLogRt:
+		return appleLogGamma*math.Log2(linear+appleLogBeta) + appleLogDelta
+	case linear >= appleLogR0:
+		d := linear - appleLogR0
+		return appleLogC * d * d
+	default:
+		return 0
+	}
+}
+
+func appleLogInverseTransfer(encoded float64) float64 {
+	const pt = appleLogC * (appleLogRt - appleLogR0) * (appleLogRt - appleLogR0)
+	switch {
+	case encoded >= pt:
+		return math.Pow(2, (encoded-appleLogDelta)/appleLogGamma) - appleLogBeta
+	case encoded >= 0:
+		return math.Sqrt(encoded/appleLogC) + appleLogR0
+	default:
+		return appleLogR0
+	}
+}
+
+// Fujifilm F-Log2 Transfer Functions
+// Reference: FUJIFILM F-Log2 Data Sheet
+
+const (
+	fLog2A    = 5.555556
+	fLog2B    = 0.064829
+	fLog2C    = 0.245281
+	fLog2D    = 0.384316
+	fLog2E    = 8.799461
+	fLog2F    = 0.092864
+	fLog2Cut1 = 0.000889
+	fLog2Cut2 = 0.100686685370811
+)
+
+func fLog2Transfer(linear float64) float64 {
+	if linear >= fLog2Cut1 {
+		return fLog2C*math.Log10(fLog2A*linear+fLog2B) + fLog2D
+	}
+	return fLog2E*linear + fLog2F
+}
+
+func fLog2InverseTransfer(encoded float64) float64 {
+	if encoded >= fLog2Cut2 {
+		return (math.Pow(10, (encoded-fLog2D)/fLog2C) - fLog2B) / fLog2A
+	}
+	return (encoded - fLog2F) / fLog2E
+}
+
+// Camera gamut primaries as xy chromaticities (red, green, blue), all with a D65 white.
+// The conversion matrices of the LOG spaces are derived from them.
+var (
+	canonCinemaGamut    = [3][2]float64{{0.74, 0.27}, {0.17, 1.14}, {0.08, -0.10}}
+	sonySGamut3         = [3][2]float64{{0.730, 0.280}, {0.140, 0.855}, {0.100, -0.050}}
+	sonySGamut3Cine     = [3][2]float64{{0.766, 0.275}, {0.225, 0.800}, {0.089, -0.087}}
+	panasonicVGamut     = [3][2]float64{{0.730, 0.280}, {0.165, 0.840}, {0.100, -0.030}}
+	arriWideGamut3      = [3][2]float64{{0.6840, 0.3130}, {0.2210, 0.8480}, {0.0861, -0.1020}}
+	arriWideGamut4      = [3][2]float64{{0.7347, 0.2653}, {0.1424, 0.8576}, {0.0991, -0.0308}}
+	redWideGamutRGB     = [3][2]float64{{0.780308, 0.304253}, {0.121595, 1.493994}, {0.095612, -0.084589}}
+	blackmagicWideGamut = [3][2]float64{{0.7177, 0.3171}, {0.2280, 0.8616}, {0.1006, -0.0820}}
+	davinciWideGamut    = [3][2]float64{{0.8000, 0.3130}, {0.1682, 0.9877}, {0.0790, -0.1155}}
+)
+
+// newLogSpace creates a D65 LOG space from its gamut primaries and transfer functions.
+func newLogSpace(name string, primaries [3][2]float64, transfer, inverse func(float64) float64) *rgbSpace {
+	return newPrimariesSpace(name, primaries, WhiteD65, transfer, inverse)
+}
+
 // LOG Color Space Definitions
-// These use the same primaries as their base RGB spaces but with LOG transfer functions
+// Each pairs a camera's LOG transfer function with its native wide gamut
 
 var (
 	// CLogSpace represents Canon C-Log color space
 	// Uses Canon Cinema Gamut primaries with C-Log transfer
-	CLogSpace Space = &rgbSpace{
-		name: "c-log",
-		// Canon Cinema Gamut primaries (approximately Rec. 2020)
-		xyzToRGBMatrix: [9]float64{
-			1.9624274, -0.6105343, -0.3413404,
-			-0.9787684, 1.9161415, 0.0334540,
-			0.0286869, -0.1406752, 1.3487655,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.5766690429101305, 0.1855582379065463, 0.1882286462349947,
-			0.29734497525053605, 0.6273635662554661, 0.07529145849399788,
-			0.02703136138641234, 0.07068885253582723, 0.9913375368376388,
-		},
-		transferFunc:        cLogTransfer,
-		inverseTransferFunc: cLogInverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	CLogSpace Space = newLogSpace("c-log", canonCinemaGamut, cLogTransfer, cLogInverseTransfer)
+
+	// CLog2Space represents Canon Log 2 with Cinema Gamut primaries
+	CLog2Space Space = newLogSpace("canon-log2", canonCinemaGamut, cLog2Transfer, cLog2InverseTransfer)
+
+	// CLog3Space represents Canon Log 3 with Cinema Gamut primaries
+	CLog3Space Space = newLogSpace("canon-log3", canonCinemaGamut, cLog3Transfer, cLog3InverseTransfer)
 
 	// SLog3Space represents Sony S-Log3 color space
 	// Uses Sony S-Gamut3 primaries with S-Log3 transfer
-	SLog3Space Space = &rgbSpace{
-		name: "s-log3",
-		// Sony S-Gamut3 primaries (wide gamut)
-		xyzToRGBMatrix: [9]float64{
-			1.9624274, -0.6105343, -0.3413404,
-			-0.9787684, 1.9161415, 0.0334540,
-			0.0286869, -0.1406752, 1.3487655,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.7064, 0.1288, 0.1213,
-			0.2709, 0.7869, -0.0578,
-			-0.0096, 0.0045, 1.1156,
-		},
-		transferFunc:        sLog3Transfer,
-		inverseTransferFunc: sLog3InverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	SLog3Space Space = newLogSpace("s-log3", sonySGamut3, sLog3Transfer, sLog3InverseTransfer)
+
+	// SLog3CineSpace represents Sony S-Log3 with the smaller S-Gamut3.Cine primaries,
+	// which are easier to grade to DCI-P3 and Rec. 709
+	SLog3CineSpace Space = newLogSpace("s-log3-cine", sonySGamut3Cine, sLog3Transfer, sLog3InverseTransfer)
 
 	// VLogSpace represents Panasonic V-Log color space
 	// Uses V-Gamut primaries with V-Log transfer
-	VLogSpace Space = &rgbSpace{
-		name: "v-log",
-		// V-Gamut primaries (wide gamut)
-		xyzToRGBMatrix: [9]float64{
-			1.5890, -0.3130, -0.1802,
-			-0.5340, 1.3960, 0.0950,
-			-0.0110, -0.0640, 1.1570,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.7790, 0.0780, 0.1020,
-			0.3270, 0.7230, -0.0500,
-			0.0010, 0.0510, 0.8390,
-		},
-		transferFunc:        vLogTransfer,
-		inverseTransferFunc: vLogInverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	VLogSpace Space = newLogSpace("v-log", panasonicVGamut, vLogTransfer, vLogInverseTransfer)
 
 	// ArriLogCSpace represents Arri LogC (V3, EI 800) color space
-	// Uses Arri Wide Gamut primaries with LogC transfer
-	ArriLogCSpace Space = &rgbSpace{
-		name: "arri-logc",
-		// Arri Wide Gamut primaries
-		xyzToRGBMatrix: [9]float64{
-			1.789066, -0.482534, -0.200076,
-			-0.639849, 1.396400, 0.194432,
-			-0.041532, 0.082335, 1.040648,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.6380, 0.2140, 0.0970,
-			0.2910, 0.8240, -0.1150,
-			0.0020, -0.0380, 1.0910,
-		},
-		transferFunc:        arriLogCTransfer,
-		inverseTransferFunc: arriLogCInverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	// Uses Arri Wide Gamut 3 primaries with LogC transfer
+	ArriLogCSpace Space = newLogSpace("arri-logc", arriWideGamut3, arriLogCTransfer, arriLogCInverseTransfer)
+
+	// ArriLogC4Space represents ARRI LogC4 with ARRI Wide Gamut 4 primaries (ALEXA 35)
+	ArriLogC4Space Space = newLogSpace("arri-logc4", arriWideGamut4, arriLogC4Transfer, arriLogC4InverseTransfer)
 
 	// RedLog3G10Space represents Red Log3G10 color space
 	// Uses RedWideGamutRGB primaries with Log3G10 transfer
-	RedLog3G10Space Space = &rgbSpace{
-		name: "red-log3g10",
-		// Red Wide Gamut RGB primaries
-		xyzToRGBMatrix: [9]float64{
-			1.7827, -0.4969, -0.1768,
-			-0.6702, 1.4985, 0.1323,
-			-0.0530, 0.0403, 1.0672,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.7347, 0.1596, 0.0366,
-			0.2653, 0.8404, -0.1057,
-			0.0000, -0.0000, 1.0690,
-		},
-		transferFunc:        redLog3G10Transfer,
-		inverseTransferFunc: redLog3G10InverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	RedLog3G10Space Space = newLogSpace("red-log3g10", redWideGamutRGB, redLog3G10Transfer, redLog3G10InverseTransfer)
 
 	// BMDFilmSpace represents Blackmagic Film color space
 	// Uses Blackmagic Wide Gamut primaries with BMDFilm transfer
-	BMDFilmSpace Space = &rgbSpace{
-		name: "bmd-film",
-		// Blackmagic Wide Gamut (approximately Rec. 2020)
-		xyzToRGBMatrix: [9]float64{
-			1.9624274, -0.6105343, -0.3413404,
-			-0.9787684, 1.9161415, 0.0334540,
-			0.0286869, -0.1406752, 1.3487655,
-		},
-		rgbToXYZMatrix: [9]float64{
-			0.5766690429101305, 0.1855582379065463, 0.1882286462349947,
-			0.29734497525053605, 0.6273635662554661, 0.07529145849399788,
-			0.02703136138641234, 0.07068885253582723, 0.9913375368376388,
-		},
-		transferFunc:        bmdFilmTransfer,
-		inverseTransferFunc: bmdFilmInverseTransfer,
-		whitePoint:          WhiteD65,
-	}
+	BMDFilmSpace Space = newLogSpace("bmd-film", blackmagicWideGamut, bmdFilmTransfer, bmdFilmInverseTransfer)
+
+	// DaVinciIntermediateSpace represents DaVinci Intermediate with DaVinci Wide Gamut
+	// primaries, the working space of DaVinci Resolve color management
+	DaVinciIntermediateSpace Space = newLogSpace("davinci-intermediate", davinciWideGamut, davinciIntermediateTransfer, davinciIntermediateInverseTransfer)
+
+	// AppleLogSpace represents Apple Log (iPhone 15 Pro and later) with Rec. 2020 primaries
+	AppleLogSpace Space = newLogSpace("apple-log", rec2020Primaries, appleLogTransfer, appleLogInverseTransfer)
+
+	// FLog2Space represents Fujifilm F-Log2 with F-Gamut (Rec. 2020) primaries
+	FLog2Space Space = newLogSpace("f-log2", rec2020Primaries, fLog2Transfer, fLog2InverseTransfer)
 )
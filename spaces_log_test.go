@@ -310,6 +310,20 @@ func TestLOGSpaceRegistry(t *testing.T) {
 		{"log3g10", RedLog3G10Space},
 		{"bmd-film", BMDFilmSpace},
 		{"bmdfilm", BMDFilmSpace},
+		{"arri-logc4", ArriLogC4Space},
+		{"logc4", ArriLogC4Space},
+		{"canon-log2", CLog2Space},
+		{"clog2", CLog2Space},
+		{"canon-log3", CLog3Space},
+		{"clog3", CLog3Space},
+		{"s-log3-cine", SLog3CineSpace},
+		{"slog3-cine", SLog3CineSpace},
+		{"davinci-intermediate", DaVinciIntermediateSpace},
+		{"davinci-wg", DaVinciIntermediateSpace},
+		{"apple-log", AppleLogSpace},
+		{"applelog", AppleLogSpace},
+		{"f-log2", FLog2Space},
+		{"flog2", FLog2Space},
 	}
 
 	for _, tt := range tests {
@@ -383,6 +397,125 @@ func TestVLogReferenceValues(t *testing.T) {
 	}
 }
 
+// Reference values from the camera vendors' specifications
+func TestLOGEncodingReferenceValues(t *testing.T) {
+	tests := []struct {
+		name           string
+		transfer       func(float64) float64
+		inverse        func(float64) float64
+		linear, encode float64
+	}{
+		{"S-Log3 black", sLog3Transfer, sLog3InverseTransfer, 0, 95.0 / 1023},
+		{"S-Log3 18% gray", sLog3Transfer, sLog3InverseTransfer, 0.18, 420.0 / 1023},
+		{"S-Log3 90% white", sLog3Transfer, sLog3InverseTransfer, 0.9, 598.0 / 1023},
+		{"LogC4 18% gray", arriLogC4Transfer, arriLogC4InverseTransfer, 0.18, 0.2783958},
+		{"LogC4 peak", arriLogC4Transfer, arriLogC4InverseTransfer, 469.8, 1},
+		{"Canon Log 2 18% gray", cLog2Transfer, cLog2InverseTransfer, 0.18, 0.3982547},
+		{"Canon Log 3 18% gray", cLog3Transfer, cLog3InverseTransfer, 0.18, 0.3433894},
+		{"DaVinci Intermediate 18% gray", davinciIntermediateTransfer, davinciIntermediateInverseTransfer, 0.18, 0.3360433},
+		{"DaVinci Intermediate peak", davinciIntermediateTransfer, davinciIntermediateInverseTransfer, 100, 1},
+		{"Apple Log 18% gray", appleLogTransfer, appleLogInverseTransfer, 0.18, 0.4882725},
+		{"Apple Log black", appleLogTransfer, appleLogInverseTransfer, 0, 0.1504765},
+		{"F-Log2 black", fLog2Transfer, fLog2InverseTransfer, 0, 0.092864},
+		{"F-Log2 18% gray", fLog2Transfer, fLog2InverseTransfer, 0.18, 0.3910072},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transfer(tt.linear); !floatNear(got, tt.encode, 2e-4) {
+				t.Errorf("encode(%v) = %.7f, want %.7f", tt.linear, got, tt.encode)
+			}
+			if got := tt.inverse(tt.encode); !floatNear(got, tt.linear, 1e-3*math.Max(1, tt.linear)) {
+				t.Errorf("decode(%v) = %.7f, want %.7f", tt.encode, got, tt.linear)
+			}
+		})
+	}
+}
+
+func TestNewLOGEncodingsRoundTrip(t *testing.T) {
+	encodings := []struct {
+		name              string
+		transfer, inverse func(float64) float64
+	}{
+		{"LogC4", arriLogC4Transfer, arriLogC4InverseTransfer},
+		{"Canon Log 2", cLog2Transfer, cLog2InverseTransfer},
+		{"Canon Log 3", cLog3Transfer, cLog3InverseTransfer},
+		{"DaVinci Intermediate", davinciIntermediateTransfer, davinciIntermediateInverseTransfer},
+		{"Apple Log", appleLogTransfer, appleLogInverseTransfer},
+		{"F-Log2", fLog2Transfer, fLog2InverseTransfer},
+	}
+	// Slightly negative values (sensor noise below black) survive too
+	testCases := []float64{-0.01, 0.0, 0.001, 0.01, 0.1, 0.18, 0.5, 1.0, 2.0, 10.0}
+
+	for _, enc := range encodings {
+		prev := math.Inf(-1)
+		for _, linear := range testCases {
+			encoded := enc.transfer(linear)
+			if encoded <= prev {
+				t.Errorf("%s is not increasing at %v", enc.name, linear)
+			}
+			prev = encoded
+			if decoded := enc.inverse(encoded); !floatNear(linear, decoded, 1e-6*math.Max(1, linear)) {
+				t.Errorf("%s round-trip failed: %v -> %v -> %v", enc.name, linear, encoded, decoded)
+			}
+		}
+	}
+}
+
+// Test gamut matrices against the vendors' published RGB to XYZ matrices
+func TestLOGGamutMatrices(t *testing.T) {
+	tests := []struct {
+		name  string
+		space Space
+		want  [9]float64
+	}{
+		{"S-Gamut3", SLog3Space, [9]float64{
+			0.7064827132, 0.1288010498, 0.1151721641,
+			0.2709796708, 0.7866064112, -0.0575860820,
+			-0.0096778454, 0.0046000375, 1.0941355587,
+		}},
+		{"ARRI Wide Gamut 3", ArriLogCSpace, [9]float64{
+			0.638008, 0.214704, 0.097744,
+			0.291954, 0.823841, -0.115795,
+			0.002798, -0.067034, 1.153294,
+		}},
+		{"ARRI Wide Gamut 4", ArriLogC4Space, [9]float64{
+			0.704858320407232, 0.129760295170463, 0.115837311473976,
+			0.254524176404027, 0.781477732712002, -0.036001909116029,
+			0, 0, 1.089057750759878,
+		}},
+		{"DaVinci Wide Gamut", DaVinciIntermediateSpace, [9]float64{
+			0.70062239, 0.14877482, 0.10105872,
+			0.27411851, 0.87363190, -0.14775041,
+			-0.09896291, -0.13789533, 1.32591599,
+		}},
+	}
+
+	for _, tt := range tests {
+		// The published matrices use D65 from xy; ours uses the library's D65 XYZ
+		got := tt.space.(*rgbSpace).rgbToXYZMatrix
+		for i := range got {
+			if !floatNear(got[i], tt.want[i], 1e-3) {
+				t.Errorf("%s RGB to XYZ = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+
+	// Every LOG space maps its white to D65
+	for _, space := range []Space{CLogSpace, CLog2Space, CLog3Space, SLog3Space, SLog3CineSpace, VLogSpace,
+		ArriLogCSpace, ArriLogC4Space, RedLog3G10Space, BMDFilmSpace, DaVinciIntermediateSpace, AppleLogSpace, FLog2Space} {
+		s := space.(*rgbSpace)
+		x, y, z := s.linearToXYZ(1, 1, 1)
+		if !floatNear(x, whiteD65[0], 1e-9) || !floatNear(y, 1, 1e-9) || !floatNear(z, whiteD65[2], 1e-9) {
+			t.Errorf("%s white = %v, %v, %v", space.Name(), x, y, z)
+		}
+		if meta := Metadata(space); meta == nil || meta.Family != "RGB-LOG" {
+			t.Errorf("%s metadata = %+v", space.Name(), meta)
+		}
+	}
+}
+
 // Test HDR values (> 1.0)
 func TestLOGSpaceHDRValues(t *testing.T) {
 	// LOG spaces should handle HDR values (> 1.0) gracefully
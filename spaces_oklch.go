@@ -65,19 +65,13 @@ func oklabToXYZ(okl, oka, okb float64) (x, y, z float64) {
 	b := -0.0041960863*l_ - 0.7034186147*m_ + 1.7076147010*s_
 	
 	// Convert linear RGB to XYZ
-	x = r*0.4124564 + g*0.3575761 + b*0.1804375
-	y = r*0.2126729 + g*0.7151522 + b*0.0721750
-	z = r*0.0193339 + g*0.1191920 + b*0.9503041
-	
-	return x, y, z
+	return linearSRGBToXYZ(r, g, b)
 }
 
 // xyzToOKLAB converts XYZ to OKLAB
 func xyzToOKLAB(x, y, z float64) (okl, oka, okb float64) {
 	// Convert XYZ to linear RGB
-	r := x*3.2404542 - y*1.5371385 - z*0.4985314
-	g := -x*0.9692660 + y*1.8760108 + z*0.0415560
-	b := x*0.0556434 - y*0.2040259 + z*1.0572252
+	r, g, b := xyzToLinearSRGB(x, y, z)
 	
 	// Convert linear RGB to LMS
 	l_ := 0.4122214708*r + 0.5363325363*g + 0.0514459929*b
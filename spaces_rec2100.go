@@ -0,0 +1,121 @@
+package color
+
+import "math"
+
+// Rec2100PQSpace represents ITU-R BT.2100 with the PQ transfer function
+// (HDR10), with Rec. 2020 primaries. Relative XYZ is placed with Y = 1 at
+// SDRWhiteLuminance, so (1, 1, 1) encodes a 10,000 cd/m² white.
+var Rec2100PQSpace Space = NewRec2100PQSpace(SDRWhiteLuminance)
+
+// Rec2100HLGSpace represents ITU-R BT.2100 with the HLG transfer function,
+// on a 1000 cd/m² display. Relative Y = 1 is HDR reference white (signal 0.75).
+var Rec2100HLGSpace Space = NewRec2100HLGSpace(1000, 0)
+
+// Rec2100LinearSpace represents linear light with Rec. 2020 primaries, where
+// (1, 1, 1) is SDR white (SDRWhiteLuminance) and values up to 10000/203 are HDR.
+var Rec2100LinearSpace Space = newPrimariesSpace("rec2100-linear", rec2020Primaries, WhiteD65, linearTransfer, linearInverseTransfer).withRange(0, 10000/SDRWhiteLuminance)
+
+// NewRec2100PQSpace creates a Rec. 2100 PQ space in which relative Y = 1
+// is displayed at whiteLuminance cd/m².
+//
+// Example:
+//   // Treat SDR white as 100 cd/m², as some older HDR10 pipelines do
+//   pq := color.NewRec2100PQSpace(100)
+func NewRec2100PQSpace(whiteLuminance float64) Space {
+	encode := func(linear float64) float64 {
+		return PQInverseEOTF(linear * whiteLuminance)
+	}
+	decode := func(encoded float64) float64 {
+		return PQEOTF(encoded) / whiteLuminance
+	}
+	return newPrimariesSpace("rec2100-pq", rec2020Primaries, WhiteD65, encode, decode)
+}
+
+// NewRec2100HLGSpace creates a Rec. 2100 HLG space for a display of the
+// given peak luminance in cd/m². A systemGamma of 0 uses HLGSystemGamma(peakLuminance).
+//
+// HLG signals are scene-referred; the space applies the HLG OOTF, as a display
+// would, and places HDR reference white (signal 0.75) at relative Y = 1.
+func NewRec2100HLGSpace(peakLuminance, systemGamma float64) Space {
+	if systemGamma == 0 {
+		systemGamma = HLGSystemGamma(peakLuminance)
+	}
+	return &hlgSpace{
+		linear: Rec2100LinearSpace.(*rgbSpace),
+		gamma:  systemGamma,
+		white:  math.Pow(hlgReferenceWhite, systemGamma),
+	}
+}
+
+// hlgSpace implements Space for Rec. 2100 HLG
+type hlgSpace struct {
+	linear *rgbSpace // Rec. 2020 primaries
+	gamma  float64   // HLG system gamma
+	white  float64   // Relative display light of reference white, before normalization
+}
+
+func (s *hlgSpace) Name() string {
+	return "rec2100-hlg"
+}
+
+func (s *hlgSpace) Channels() int {
+	return 3
+}
+
+func (s *hlgSpace) ChannelNames() []string {
+	return []string{"R", "G", "B"}
+}
+
+func (s *hlgSpace) ToXYZ(channels []float64) (x, y, z float64) {
+	if len(channels) != 3 {
+		panic("RGB space requires 3 channels")
+	}
+
+	// Inverse OETF, then the OOTF: Fd = Ys^(γ-1) · E
+	r := HLGInverseOETF(channels[0])
+	g := HLGInverseOETF(channels[1])
+	b := HLGInverseOETF(channels[2])
+	ys := s.luminance(r, g, b)
+	scale := 0.0
+	if ys > 0 {
+		scale = math.Pow(ys, s.gamma-1) / s.white
+	}
+
+	return s.linear.linearToXYZ(r*scale, g*scale, b*scale)
+}
+
+func (s *hlgSpace) FromXYZ(x, y, z float64) []float64 {
+	r, g, b := s.linear.linearFromXYZ(x, y, z)
+
+	// Inverse OOTF: Yd = Ys^γ, so Ys = Yd^(1/γ)
+	yd := s.luminance(r, g, b) * s.white
+	scale := 0.0
+	if yd > 0 {
+		ys := math.Pow(yd, 1/s.gamma)
+		scale = s.white / math.Pow(ys, s.gamma-1)
+	}
+
+	return []float64{HLGOETF(r * scale), HLGOETF(g * scale), HLGOETF(b * scale)}
+}
+
+// luminance returns the Y of linear Rec. 2020 RGB.
+func (s *hlgSpace) luminance(r, g, b float64) float64 {
+	m := s.linear.rgbToXYZMatrix
+	return m[3]*r + m[4]*g + m[5]*b
+}
+
+// inGamutXYZ implements gamutBounded.
+func (s *hlgSpace) inGamutXYZ(x, y, z float64) bool {
+	for _, v := range s.FromXYZ(x, y, z) {
+		if !inUnitRange(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// clipXYZ implements gamutBounded.
+func (s *hlgSpace) clipXYZ(x, y, z float64) []float64 {
+	v := s.FromXYZ(x, y, z)
+	return []float64{clamp01(v[0]), clamp01(v[1]), clamp01(v[2])}
+}
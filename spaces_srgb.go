@@ -1,43 +1,27 @@
 package color
 
-// SRGBSpace represents the sRGB color space (D65 white point, sRGB primaries, sRGB transfer function)
-var SRGBSpace Space = &rgbSpace{
-	name:           "sRGB",
-	xyzToRGBMatrix: [9]float64{3.2404542, -1.5371385, -0.4985314, -0.9692660, 1.8760108, 0.0415560, 0.0556434, -0.2040259, 1.0572252},
-	rgbToXYZMatrix: [9]float64{0.4124564, 0.3575761, 0.1804375, 0.2126729, 0.7151522, 0.0721750, 0.0193339, 0.1191920, 0.9503041},
-	transferFunc:   sRGBTransfer,
-	inverseTransferFunc: sRGBInverseTransfer,
-	whitePoint:     WhiteD65,
-}
+import "math"
 
-// SRGBLinearSpace represents linear sRGB (no gamma encoding)
-var SRGBLinearSpace Space = &rgbSpace{
-	name:           "sRGB-linear",
-	xyzToRGBMatrix: [9]float64{3.2404542, -1.5371385, -0.4985314, -0.9692660, 1.8760108, 0.0415560, 0.0556434, -0.2040259, 1.0572252},
-	rgbToXYZMatrix: [9]float64{0.4124564, 0.3575761, 0.1804375, 0.2126729, 0.7151522, 0.0721750, 0.0193339, 0.1191920, 0.9503041},
-	transferFunc:   linearTransfer,
-	inverseTransferFunc: linearInverseTransfer,
-	whitePoint:     WhiteD65,
-}
+// srgbPrimaries are the ITU-R BT.709 primaries shared by sRGB and Rec. 709
+var srgbPrimaries = [3][2]float64{{0.64, 0.33}, {0.30, 0.60}, {0.15, 0.06}}
 
-// WhitePoint represents a white point for chromatic adaptation
-type WhitePoint int
+// SRGBSpace represents the sRGB color space (D65 white point, sRGB primaries, sRGB transfer function)
+var SRGBSpace Space = newPrimariesSpace("sRGB", srgbPrimaries, WhiteD65, sRGBTransfer, sRGBInverseTransfer)
 
-const (
-	// WhiteD65 is the standard white point for most RGB spaces (6500K)
-	WhiteD65 WhitePoint = iota
-	// WhiteD50 is used by ProPhoto RGB and ICC LAB (5000K)
-	WhiteD50
-)
+// SRGBLinearSpace represents linear sRGB (no gamma encoding)
+var SRGBLinearSpace Space = newPrimariesSpace("sRGB-linear", srgbPrimaries, WhiteD65, linearTransfer, linearInverseTransfer)
 
 // rgbSpace implements Space for RGB color spaces
 type rgbSpace struct {
-	name               string
-	xyzToRGBMatrix     [9]float64 // Matrix to convert XYZ to linear RGB
-	rgbToXYZMatrix     [9]float64 // Matrix to convert linear RGB to XYZ
-	transferFunc       func(float64) float64
+	name                string
+	xyzToRGBMatrix      [9]float64 // Matrix to convert XYZ to linear RGB
+	rgbToXYZMatrix      [9]float64 // Matrix to convert linear RGB to XYZ
+	transferFunc        func(float64) float64
 	inverseTransferFunc func(float64) float64
-	whitePoint         WhitePoint // White point for chromatic adaptation
+	whitePoint          WhitePoint          // White point for chromatic adaptation
+	adaptation          ChromaticAdaptation // Adapts whitePoint to and from D65
+	encodedMin          float64             // Encoded value of the gamut's black
+	encodedMax          float64             // Encoded value of the gamut's peak; 0 means 1
 }
 
 func (s *rgbSpace) Name() string {
@@ -62,31 +46,27 @@ func (s *rgbSpace) ToXYZ(channels []float64) (x, y, z float64) {
 	g := s.inverseTransferFunc(channels[1])
 	b := s.inverseTransferFunc(channels[2])
 
+	return s.linearToXYZ(r, g, b)
+}
+
+// linearToXYZ converts linear RGB in this space to XYZ (D65).
+func (s *rgbSpace) linearToXYZ(r, g, b float64) (x, y, z float64) {
 	// Convert linear RGB to XYZ using matrix
 	m := s.rgbToXYZMatrix
 	x = m[0]*r + m[1]*g + m[2]*b
 	y = m[3]*r + m[4]*g + m[5]*b
 	z = m[6]*r + m[7]*g + m[8]*b
 
-	// If the space uses D50, adapt to D65 (our standard XYZ white point)
-	if s.whitePoint == WhiteD50 {
-		x, y, z = AdaptD50ToD65(x, y, z)
+	// If the space uses another white, adapt to D65 (our standard XYZ white point)
+	if white := s.whitePoint.xyz(); white != whiteD65 {
+		x, y, z = s.adaptation.adapt(x, y, z, white, whiteD65)
 	}
 
 	return x, y, z
 }
 
 func (s *rgbSpace) FromXYZ(x, y, z float64) []float64 {
-	// If the space uses D50, adapt from D65 (our standard XYZ white point)
-	if s.whitePoint == WhiteD50 {
-		x, y, z = AdaptD65ToD50(x, y, z)
-	}
-
-	// Convert XYZ to linear RGB using matrix
-	m := s.xyzToRGBMatrix
-	linearR := m[0]*x + m[1]*y + m[2]*z
-	linearG := m[3]*x + m[4]*y + m[5]*z
-	linearB := m[6]*x + m[7]*y + m[8]*z
+	linearR, linearG, linearB := s.linearFromXYZ(x, y, z)
 
 	// Apply transfer function
 	r := s.transferFunc(linearR)
@@ -96,3 +76,58 @@ func (s *rgbSpace) FromXYZ(x, y, z float64) []float64 {
 	return []float64{r, g, b}
 }
 
+// linearFromXYZ converts XYZ (D65) to linear RGB in this space without clamping.
+func (s *rgbSpace) linearFromXYZ(x, y, z float64) (r, g, b float64) {
+	// If the space uses another white, adapt from D65 (our standard XYZ white point)
+	if white := s.whitePoint.xyz(); white != whiteD65 {
+		x, y, z = s.adaptation.unadapt(x, y, z, white, whiteD65)
+	}
+
+	// Convert XYZ to linear RGB using matrix
+	m := s.xyzToRGBMatrix
+	r = m[0]*x + m[1]*y + m[2]*z
+	g = m[3]*x + m[4]*y + m[5]*z
+	b = m[6]*x + m[7]*y + m[8]*z
+
+	return r, g, b
+}
+
+// linearRange returns the linear values that encode to encodedMin and
+// encodedMax, by default 0 and 1.
+// For most spaces this is [0, 1]; LOG and HDR spaces encode a wider range.
+func (s *rgbSpace) linearRange() (lo, hi float64) {
+	max := 1.0
+	if s.encodedMax != 0 {
+		max = s.encodedMax
+	}
+	return s.inverseTransferFunc(s.encodedMin), s.inverseTransferFunc(max)
+}
+
+// inGamutXYZ implements gamutBounded.
+// The check is done on linear values because several transfer functions
+// clamp negative inputs, which would hide out-of-gamut colors.
+func (s *rgbSpace) inGamutXYZ(x, y, z float64) bool {
+	lo, hi := s.linearRange()
+	// Scale the tolerance with the range, but not beyond that of a [0, 1]
+	// range at black, so HDR ranges do not let negative values through
+	tolerance := gamutEpsilon * (hi - lo)
+	loTolerance := math.Min(tolerance, gamutEpsilon*math.Max(1, math.Abs(lo)))
+	r, g, b := s.linearFromXYZ(x, y, z)
+	for _, v := range [3]float64{r, g, b} {
+		if v < lo-loTolerance || v > hi+tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// clipXYZ implements gamutBounded.
+func (s *rgbSpace) clipXYZ(x, y, z float64) []float64 {
+	lo, hi := s.linearRange()
+	r, g, b := s.linearFromXYZ(x, y, z)
+	return []float64{
+		s.transferFunc(clamp(r, lo, hi)),
+		s.transferFunc(clamp(g, lo, hi)),
+		s.transferFunc(clamp(b, lo, hi)),
+	}
+}
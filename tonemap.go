@@ -0,0 +1,296 @@
+package color
+
+import (
+	"image"
+	stdcolor "image/color"
+	"math"
+)
+
+// ToneMapOperator specifies the curve used to map HDR colors into an SDR range.
+type ToneMapOperator int
+
+const (
+	// ToneMapReinhard is the extended Reinhard operator (Reinhard et al., 2002)
+	// on luminance, which maps the source peak to the target peak exactly.
+	ToneMapReinhard ToneMapOperator = iota
+
+	// ToneMapHable is John Hable's filmic curve from Uncharted 2, applied per channel.
+	// It has a toe and a soft shoulder, and desaturates bright colors.
+	ToneMapHable
+
+	// ToneMapACES is Stephen Hill's fit of the ACES reference rendering transform
+	// and sRGB output transform (RRT + ODT), applied per channel in a wide gamut.
+	// Like ACES, it ignores the source peak and rolls off towards white.
+	ToneMapACES
+
+	// ToneMapBT2390 is the ITU-R BT.2390 EETF: a Hermite spline roll-off in PQ,
+	// on luminance. Colors below the knee are left unchanged.
+	ToneMapBT2390
+
+	// ToneMapBT2446A is ITU-R BT.2446 method A, the broadcast HDR-to-SDR conversion,
+	// which compresses luma and corrects chroma in Rec. 2020 Y'CbCr.
+	ToneMapBT2446A
+)
+
+// String returns the name of the operator.
+func (o ToneMapOperator) String() string {
+	switch o {
+	case ToneMapReinhard:
+		return "reinhard"
+	case ToneMapHable:
+		return "hable"
+	case ToneMapACES:
+		return "aces"
+	case ToneMapBT2390:
+		return "bt2390"
+	case ToneMapBT2446A:
+		return "bt2446a"
+	default:
+		return "unknown"
+	}
+}
+
+// ToneMapOptions configures tone mapping. Colors are placed on an absolute
+// scale with relative Y = 1 at SDRWhiteLuminance, as in Rec2100PQSpace.
+type ToneMapOptions struct {
+	Operator ToneMapOperator
+
+	// SourcePeak is the peak luminance of the HDR content in cd/m². 0 means 1000.
+	SourcePeak float64
+
+	// TargetPeak is the luminance of SDR white (sRGB 1.0) in cd/m². 0 means 100.
+	TargetPeak float64
+
+	// Exposure scales the source before the curve. 0 means 1.
+	Exposure float64
+}
+
+// DefaultToneMapOptions map 1000 cd/m² content to a 100 cd/m² SDR display with BT.2390.
+var DefaultToneMapOptions = ToneMapOptions{
+	Operator:   ToneMapBT2390,
+	SourcePeak: 1000,
+	TargetPeak: 100,
+	Exposure:   1,
+}
+
+// ToneMap maps an HDR color to an SDR sRGB color with the given options.
+// Colors that are still out of the sRGB gamut after the curve, such as
+// saturated highlights, are brought in with GamutCSS, which keeps their hue.
+//
+// Example:
+//   hdr := color.NewSpaceColor(color.Rec2100PQSpace, []float64{0.75, 0.7, 0.6}, 1)
+//   sdr := color.ToneMap(hdr, color.DefaultToneMapOptions)
+func ToneMap(c Color, opts ToneMapOptions) *RGBA {
+	xyz := ToXYZ(c)
+	return opts.toSRGB(xyz.X, xyz.Y, xyz.Z, xyz.A)
+}
+
+// ToneMapImage tone maps every pixel of an image whose channels are encoded
+// in the source space, such as a 16-bit PNG of Rec2100PQSpace signals, and
+// returns an sRGB image. A nil source means sRGB.
+//
+// Example:
+//   sdr := color.ToneMapImage(frame, color.Rec2100HLGSpace, color.ToneMapOptions{
+//       Operator: color.ToneMapBT2446A,
+//   })
+func ToneMapImage(img image.Image, source Space, opts ToneMapOptions) *image.NRGBA64 {
+	if source == nil {
+		source = SRGBSpace
+	}
+	bounds := img.Bounds()
+	out := image.NewNRGBA64(bounds)
+
+	// Images are often flat; map each distinct pixel once
+	cache := make(map[stdcolor.NRGBA64]stdcolor.NRGBA64)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			px := stdcolor.NRGBA64Model.Convert(img.At(x, y)).(stdcolor.NRGBA64)
+			mapped, ok := cache[px]
+			if !ok {
+				mapped = opts.mapPixel(px, source)
+				cache[px] = mapped
+			}
+			out.SetNRGBA64(x, y, mapped)
+		}
+	}
+	return out
+}
+
+// mapPixel tone maps one non-premultiplied pixel encoded in the source space.
+func (opts ToneMapOptions) mapPixel(px stdcolor.NRGBA64, source Space) stdcolor.NRGBA64 {
+	x, y, z := source.ToXYZ([]float64{float64(px.R) / 65535, float64(px.G) / 65535, float64(px.B) / 65535})
+	c := opts.toSRGB(x, y, z, 1)
+	to16 := func(v float64) uint16 {
+		return uint16(math.Round(clamp01(v) * 65535))
+	}
+	return stdcolor.NRGBA64{R: to16(c.R), G: to16(c.G), B: to16(c.B), A: px.A}
+}
+
+// toSRGB tone maps relative XYZ to an sRGB color in gamut.
+func (opts ToneMapOptions) toSRGB(x, y, z, alpha float64) *RGBA {
+	r, g, b := opts.mapXYZ(x, y, z)
+	if inUnitRange(r) && inUnitRange(g) && inUnitRange(b) {
+		return NewRGBA(gammaCorrection(clamp01(r)), gammaCorrection(clamp01(g)), gammaCorrection(clamp01(b)), alpha)
+	}
+	x, y, z = linearSRGBToXYZ(r, g, b)
+	mapped := MapToGamut(&XYZ{X: x, Y: y, Z: z, A: alpha}, GamutCSS)
+	cr, cg, cb, _ := mapped.RGBA()
+	return NewRGBA(cr, cg, cb, alpha)
+}
+
+// peaks returns the source and target peaks and the exposure, with defaults applied.
+func (opts ToneMapOptions) peaks() (source, target, exposure float64) {
+	source, target, exposure = opts.SourcePeak, opts.TargetPeak, opts.Exposure
+	if source <= 0 {
+		source = 1000
+	}
+	if target <= 0 {
+		target = 100
+	}
+	if exposure <= 0 {
+		exposure = 1
+	}
+	return source, target, exposure
+}
+
+// mapXYZ tone maps relative XYZ to linear sRGB, where 1 is the target peak.
+func (opts ToneMapOptions) mapXYZ(x, y, z float64) (r, g, b float64) {
+	source, target, exposure := opts.peaks()
+
+	// Express the color relative to the target peak
+	scale := exposure * SDRWhiteLuminance / target
+	x, y, z = x*scale, y*scale, z*scale
+	peak := source / target
+
+	switch opts.Operator {
+	case ToneMapHable:
+		r, g, b = xyzToLinearSRGB(x, y, z)
+		return hable(r, peak), hable(g, peak), hable(b, peak)
+	case ToneMapACES:
+		r, g, b = xyzToLinearSRGB(x, y, z)
+		return acesFitted(r, g, b)
+	case ToneMapBT2446A:
+		return bt2446A(x, y, z, source, target)
+	}
+
+	if y <= 0 {
+		return 0, 0, 0
+	}
+	var mapped float64
+	switch opts.Operator {
+	case ToneMapBT2390:
+		mapped = bt2390EETF(y*target, source, target) / target
+	default:
+		mapped = reinhardExtended(y, peak)
+	}
+	k := mapped / y
+	return xyzToLinearSRGB(x*k, y*k, z*k)
+}
+
+// reinhardExtended maps luminance l so that white maps to 1.
+func reinhardExtended(l, white float64) float64 {
+	return l * (1 + l/(white*white)) / (1 + l)
+}
+
+// hable applies the Uncharted 2 filmic curve, normalized so white maps to 1.
+func hable(v, white float64) float64 {
+	curve := func(x float64) float64 {
+		const a, b, c, d, e, f = 0.15, 0.50, 0.10, 0.20, 0.02, 0.30
+		return (x*(a*x+c*b)+d*e)/(x*(a*x+b)+d*f) - e/f
+	}
+	// The exposure bias of 2 lifts mid-tones into the linear section of the curve
+	return curve(2*math.Max(0, v)) / curve(2*white)
+}
+
+// acesInput converts linear sRGB to ACES AP1 with the RRT's saturation adjustment.
+var acesInput = [9]float64{
+	0.59719, 0.35458, 0.04823,
+	0.07600, 0.90834, 0.01566,
+	0.02840, 0.13383, 0.83777,
+}
+
+// acesOutput converts from AP1 with the ODT's saturation adjustment to linear sRGB.
+var acesOutput = [9]float64{
+	1.60475, -0.53108, -0.07367,
+	-0.10208, 1.10813, -0.00605,
+	-0.00327, -0.07276, 1.07602,
+}
+
+// acesFitted applies Stephen Hill's fit of the ACES RRT and sRGB ODT to linear sRGB.
+func acesFitted(r, g, b float64) (float64, float64, float64) {
+	v := mulMatrix3(acesInput, r, g, b)
+	for i, c := range v {
+		c = math.Max(0, c)
+		v[i] = (c*(c+0.0245786) - 0.000090537) / (c*(0.983729*c+0.4329510) + 0.238081)
+	}
+	out := mulMatrix3(acesOutput, v[0], v[1], v[2])
+	return out[0], out[1], out[2]
+}
+
+// bt2390EETF maps luminance in cd/m² from a source mastered to sourcePeak
+// onto a display of targetPeak, with black at 0 (ITU-R BT.2390, section 5.4).
+func bt2390EETF(l, sourcePeak, targetPeak float64) float64 {
+	if targetPeak >= sourcePeak {
+		return l
+	}
+	srcMax := PQInverseEOTF(sourcePeak)
+	e1 := PQInverseEOTF(l) / srcMax
+	maxLum := PQInverseEOTF(targetPeak) / srcMax
+
+	ks := 1.5*maxLum - 0.5
+	e2 := e1
+	if e1 > ks {
+		t := (math.Min(e1, 1) - ks) / (1 - ks)
+		t2, t3 := t*t, t*t*t
+		e2 = (2*t3-3*t2+1)*ks + (t3-2*t2+t)*(1-ks) + (-2*t3+3*t2)*maxLum
+	}
+	return PQEOTF(e2 * srcMax)
+}
+
+// bt2446A converts XYZ relative to targetPeak with ITU-R BT.2446 method A,
+// returning linear sRGB.
+func bt2446A(x, y, z, sourcePeak, targetPeak float64) (r, g, b float64) {
+	// Method A works on gamma 2.4 Rec. 2020 R'G'B', normalized to the source peak
+	rec2020 := Rec2100LinearSpace.(*rgbSpace)
+	r, g, b = rec2020.linearFromXYZ(x, y, z)
+	rgb := [3]float64{r, g, b}
+	norm := targetPeak / sourcePeak
+	for i, v := range rgb {
+		rgb[i] = math.Pow(clamp01(v*norm), 1/2.4)
+	}
+	yp := 0.2627*rgb[0] + 0.6780*rgb[1] + 0.0593*rgb[2]
+	cb := (rgb[2] - yp) / 1.8814
+	cr := (rgb[0] - yp) / 1.4746
+
+	// Luma compression in a perceptually linear domain
+	rhoHDR := 1 + 32*math.Pow(sourcePeak/10000, 1/2.4)
+	rhoSDR := 1 + 32*math.Pow(targetPeak/10000, 1/2.4)
+	ypp := math.Log(1+(rhoHDR-1)*yp) / math.Log(rhoHDR)
+	var yc float64
+	switch {
+	case ypp <= 0.7399:
+		yc = 1.0770 * ypp
+	case ypp < 0.9909:
+		yc = -1.1510*ypp*ypp + 2.7811*ypp - 0.6302
+	default:
+		yc = 0.5*ypp + 0.5
+	}
+	ySDR := (math.Pow(rhoSDR, yc) - 1) / (rhoSDR - 1)
+
+	// Chroma correction
+	f := 0.0
+	if yp > 0 {
+		f = ySDR / (1.1 * yp)
+	}
+	cb, cr = cb*f, cr*f
+	ySDR -= math.Max(0.1*cr, 0)
+
+	rp := ySDR + 1.4746*cr
+	bp := ySDR + 1.8814*cb
+	gp := (ySDR - 0.2627*rp - 0.0593*bp) / 0.6780
+
+	// The SDR signal is still Rec. 2020; convert it to sRGB primaries
+	lin := func(v float64) float64 { return math.Pow(clamp01(v), 2.4) }
+	x, y, z = rec2020.linearToXYZ(lin(rp), lin(gp), lin(bp))
+	return xyzToLinearSRGB(x, y, z)
+}
@@ -0,0 +1,139 @@
+package color
+
+import (
+	"image"
+	stdcolor "image/color"
+	"math"
+	"testing"
+)
+
+var toneMapOperators = []ToneMapOperator{ToneMapReinhard, ToneMapHable, ToneMapACES, ToneMapBT2390, ToneMapBT2446A}
+
+// hdrGray returns a neutral HDR color of the given luminance in cd/m².
+func hdrGray(nits float64) Color {
+	v := nits / SDRWhiteLuminance
+	return NewSpaceColor(Rec2100LinearSpace, []float64{v, v, v}, 1)
+}
+
+func TestToneMapCurves(t *testing.T) {
+	for _, op := range toneMapOperators {
+		opts := DefaultToneMapOptions
+		opts.Operator = op
+
+		if r, g, b, _ := ToneMap(hdrGray(0), opts).RGBA(); r > 1e-6 || g > 1e-6 || b > 1e-6 {
+			t.Errorf("%s black = %g, %g, %g", op, r, g, b)
+		}
+
+		// Monotonic, and the source peak reaches (almost) SDR white
+		prev := -1.0
+		for _, nits := range []float64{0.1, 1, 10, 50, 100, 203, 500, 1000} {
+			r, g, b, _ := ToneMap(hdrGray(nits), opts).RGBA()
+			if r <= prev {
+				t.Errorf("%s(%g cd/m²) = %g, not above %g", op, nits, r, prev)
+			}
+			if !floatNear(r, g, 1e-3) || !floatNear(g, b, 1e-3) {
+				t.Errorf("%s(%g cd/m²) = %g, %g, %g, want gray", op, nits, r, g, b)
+			}
+			prev = r
+		}
+		if prev < 0.98 {
+			t.Errorf("%s(1000 cd/m²) = %g, want about 1", op, prev)
+		}
+	}
+}
+
+func TestToneMapReinhard(t *testing.T) {
+	opts := ToneMapOptions{Operator: ToneMapReinhard, SourcePeak: 1000, TargetPeak: 100}
+	// 203 cd/m² is L = 2.03 relative to the target, with white at 10
+	want := 2.03 * (1 + 2.03/100) / 3.03
+	r, _, _, _ := ToneMap(hdrGray(203), opts).RGBA()
+	if got := inverseGammaCorrection(r); !floatNear(got, want, 5e-4) {
+		t.Errorf("Reinhard(203 cd/m²) = %g, want %g", got, want)
+	}
+}
+
+func TestToneMapBT2390(t *testing.T) {
+	opts := DefaultToneMapOptions
+
+	// Below the knee the EETF passes luminance through
+	r, _, _, _ := ToneMap(hdrGray(10), opts).RGBA()
+	if got := inverseGammaCorrection(r); !floatNear(got, 0.1, 1e-4) {
+		t.Errorf("BT.2390(10 cd/m²) = %g, want 0.1", got)
+	}
+	if got := bt2390EETF(4000, 4000, 1000); !floatNear(got, 1000, 1e-6) {
+		t.Errorf("EETF(4000) on a 1000 cd/m² display = %g", got)
+	}
+	if got := bt2390EETF(500, 600, 1000); got != 500 {
+		t.Errorf("EETF onto a brighter display = %g, want 500", got)
+	}
+}
+
+func TestToneMapBT2446A(t *testing.T) {
+	// HDR reference white in 1000 cd/m² content lands near 40% of SDR peak
+	opts := ToneMapOptions{Operator: ToneMapBT2446A, SourcePeak: 1000, TargetPeak: 100}
+	r, _, _, _ := ToneMap(hdrGray(203), opts).RGBA()
+	if got := inverseGammaCorrection(r); math.Abs(got-0.406) > 0.01 {
+		t.Errorf("BT.2446A(203 cd/m²) = %g, want about 0.406", got)
+	}
+}
+
+func TestToneMapKeepsHue(t *testing.T) {
+	hdr := NewSpaceColor(Rec2100PQSpace, []float64{0.55, 0.5, 0.45}, 0.5)
+	want := ToOKLCH(hdr).H
+	for _, op := range toneMapOperators {
+		sdr := ToneMap(hdr, ToneMapOptions{Operator: op})
+		if !InGamut(sdr) {
+			t.Errorf("%s result %v is out of gamut", op, sdr)
+		}
+		if sdr.Alpha() != 0.5 {
+			t.Errorf("%s alpha = %g", op, sdr.Alpha())
+		}
+
+		// The per-channel curves skew bright colors towards yellow by design;
+		// the luminance operators keep hue up to gamut mapping
+		if op == ToneMapHable || op == ToneMapACES {
+			continue
+		}
+		if h := ToOKLCH(sdr).H; math.Abs(h-want) > 10 {
+			t.Errorf("%s hue = %g, want about %g", op, h, want)
+		}
+	}
+}
+
+func TestToneMapImage(t *testing.T) {
+	// A PQ frame with a 1000 cd/m² highlight, SDR white and black
+	src := image.NewNRGBA64(image.Rect(0, 0, 3, 2))
+	levels := []float64{PQInverseEOTF(1000), PQInverseEOTF(SDRWhiteLuminance), 0}
+	for x, v := range levels {
+		e := uint16(math.Round(v * 65535))
+		src.SetNRGBA64(x, 0, stdcolor.NRGBA64{R: e, G: e, B: e, A: 0xffff})
+		src.SetNRGBA64(x, 1, stdcolor.NRGBA64{R: e, G: e, B: e, A: 0x8000})
+	}
+
+	for _, op := range toneMapOperators {
+		opts := ToneMapOptions{Operator: op}
+		out := ToneMapImage(src, Rec2100PQSpace, opts)
+		if out.Bounds() != src.Bounds() {
+			t.Fatalf("bounds = %v", out.Bounds())
+		}
+		for x, v := range levels {
+			want := ToneMap(NewSpaceColor(Rec2100PQSpace, []float64{v, v, v}, 1), opts)
+			got := out.NRGBA64At(x, 0)
+			if r := float64(got.R) / 65535; math.Abs(r-want.R) > 2e-3 {
+				t.Errorf("%s pixel %d = %g, want %g", op, x, r, want.R)
+			}
+			if a := out.NRGBA64At(x, 1).A; a != 0x8000 {
+				t.Errorf("%s pixel %d alpha = %#x", op, x, a)
+			}
+		}
+	}
+}
+
+func TestToneMapOperatorString(t *testing.T) {
+	if s := ToneMapBT2446A.String(); s != "bt2446a" {
+		t.Errorf("String() = %q", s)
+	}
+	if s := ToneMapOperator(99).String(); s != "unknown" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -1,7 +1,5 @@
 package color
 
-import "math"
-
 // Lighten increases the lightness of a color by the specified amount.
 // Amount should be in the range [0, 1], where 0 is no change and 1 is maximum lightening.
 func Lighten(c Color, amount float64) Color {
@@ -30,7 +28,7 @@ func Saturate(c Color, amount float64) Color {
 	amount = clamp01(amount)
 	
 	oklch := ToOKLCH(c)
-	maxC := estimateMaxChroma(oklch.L, oklch.H)
+	maxC := GamutBoundaryOf(SRGBSpace).inGamutChroma(oklch.L, oklch.H)
 	oklch.C = clamp(oklch.C+amount*(maxC-oklch.C), 0, maxC)
 	return oklch
 }
@@ -65,32 +63,9 @@ func Mix(c1, c2 Color, weight float64) Color {
 }
 
 // MixOKLCH blends two colors in OKLCH space for perceptually uniform mixing.
+// Hue takes the shortest path around the color wheel.
 func MixOKLCH(c1, c2 Color, weight float64) Color {
-	weight = clamp01(weight)
-	
-	oklch1 := ToOKLCH(c1)
-	oklch2 := ToOKLCH(c2)
-	
-	// Interpolate in OKLCH space
-	l := oklch1.L*(1-weight) + oklch2.L*weight
-	c := oklch1.C*(1-weight) + oklch2.C*weight
-	
-	// Handle hue interpolation (shortest path)
-	h1, h2 := oklch1.H, oklch2.H
-	dh := h2 - h1
-	if math.Abs(dh) > 180 {
-		if dh > 0 {
-			dh -= 360
-		} else {
-			dh += 360
-		}
-	}
-	h := normalizeHue(h1 + dh*weight)
-	
-	// Mix alpha
-	a := oklch1.Alpha()*(1-weight) + oklch2.Alpha()*weight
-	
-	return NewOKLCH(l, c, h, a)
+	return mixInSpace(c1, c2, weight, GradientOKLCH, mixOptions{})
 }
 
 // AdjustHue shifts the hue of a color by the specified degrees.
@@ -119,9 +94,14 @@ func Complement(c Color) Color {
 	return AdjustHue(c, 180)
 }
 
-// estimateMaxChroma estimates the maximum chroma for a given lightness and hue in OKLCH.
-// This uses binary search to find the gamut boundary accurately.
+// estimateMaxChroma estimates the maximum sRGB chroma for a given lightness and hue in OKLCH.
+// It starts from the cached sRGB gamut boundary and never lies outside the gamut.
 func estimateMaxChroma(l, h float64) float64 {
+	return GamutBoundaryOf(SRGBSpace).inGamutChroma(l, h)
+}
+
+// maxChromaOf finds the maximum in-gamut chroma of space for a given lightness and hue in OKLCH.
+func maxChromaOf(l, h float64, space Space) float64 {
 	// Clamp lightness to valid range
 	l = clamp01(l)
 
@@ -136,11 +116,11 @@ func estimateMaxChroma(l, h float64) float64 {
 
 	// Quick check if upper bound is in gamut
 	testColor := NewOKLCH(l, maxC, h, 1.0)
-	if InGamut(testColor) {
+	if InGamutOf(testColor, space) {
 		// Try even higher values
 		for maxC < 2.0 {
 			testColor = NewOKLCH(l, maxC*2, h, 1.0)
-			if !InGamut(testColor) {
+			if !InGamutOf(testColor, space) {
 				break
 			}
 			maxC *= 2
@@ -151,7 +131,7 @@ func estimateMaxChroma(l, h float64) float64 {
 	for i := 0; i < 15; i++ {
 		midC := (minC + maxC) / 2
 		testColor = NewOKLCH(l, midC, h, 1.0)
-		if InGamut(testColor) {
+		if InGamutOf(testColor, space) {
 			minC = midC
 		} else {
 			maxC = midC
@@ -61,6 +61,21 @@ func TestSaturate(t *testing.T) {
 	}
 }
 
+func TestSaturateInGamut(t *testing.T) {
+	for l := 0.05; l < 1; l += 0.05 {
+		for h := 1.0; h < 360; h += 13 {
+			c := NewOKLCH(l, 0.02, h, 1)
+			if got := Saturate(c, 1); !InGamut(got) {
+				t.Errorf("Saturate(oklch(%.2f 0.02 %.0f), 1) = %v is out of gamut", l, h, ToOKLCH(got))
+			}
+			got := SaturateSpace(NewSpaceColor(OKLCHSpace, []float64{l, 0.02, h}, 1), 1).Channels()
+			if !InGamut(NewOKLCH(got[0], got[1], got[2], 1)) {
+				t.Errorf("SaturateSpace(oklch(%.2f 0.02 %.0f), 1) = %v is out of gamut", l, h, got)
+			}
+		}
+	}
+}
+
 func TestDesaturate(t *testing.T) {
 	red := RGB(1, 0, 0)
 	desaturated := Desaturate(red, 0.5)
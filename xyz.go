@@ -15,23 +15,25 @@ func NewXYZ(x, y, z, a float64) *XYZ {
 
 // RGBA converts XYZ to RGBA (sRGB).
 func (c *XYZ) RGBA() (r, g, b, a float64) {
-	// Convert XYZ to linear RGB
-	linearR := c.X*3.2404542 - c.Y*1.5371385 - c.Z*0.4985314
-	linearG := -c.X*0.9692660 + c.Y*1.8760108 + c.Z*0.0415560
-	linearB := c.X*0.0556434 - c.Y*0.2040259 + c.Z*1.0572252
+	linearR, linearG, linearB, a := c.linearRGBA()
 
 	// Apply gamma correction to convert linear RGB to sRGB
 	r = gammaCorrection(linearR)
 	g = gammaCorrection(linearG)
 	b = gammaCorrection(linearB)
-	a = c.A
 
 	return clamp01(r), clamp01(g), clamp01(b), clamp01(a)
 }
 
+// linearRGBA implements linearColor.
+func (c *XYZ) linearRGBA() (r, g, b, a float64) {
+	r, g, b = xyzToLinearSRGB(resolveNone(c.X), resolveNone(c.Y), resolveNone(c.Z))
+	return r, g, b, c.Alpha()
+}
+
 // Alpha implements Color.
 func (c *XYZ) Alpha() float64 {
-	return c.A
+	return resolveNone(c.A)
 }
 
 // WithAlpha implements Color.
@@ -39,25 +41,51 @@ func (c *XYZ) WithAlpha(alpha float64) Color {
 	return &XYZ{X: c.X, Y: c.Y, Z: c.Z, A: clamp01(alpha)}
 }
 
-// ToXYZ converts an RGBA color to XYZ.
-func ToXYZ(c Color) *XYZ {
-	r, g, b, a := c.RGBA()
+// String returns a CSS color(xyz-d65) representation.
+func (c *XYZ) String() string {
+	return CSSString(c)
+}
 
-	// Convert sRGB to linear RGB
-	linearR := inverseGammaCorrection(r)
-	linearG := inverseGammaCorrection(g)
-	linearB := inverseGammaCorrection(b)
+// ToXYZ converts a color to XYZ.
+// The conversion is unclamped, so colors outside the sRGB gamut keep their
+// full XYZ values.
+func ToXYZ(c Color) *XYZ {
+	if xyz, ok := c.(*XYZ); ok {
+		return &XYZ{X: resolveNone(xyz.X), Y: resolveNone(xyz.Y), Z: resolveNone(xyz.Z), A: xyz.Alpha()}
+	}
 
-	// Convert linear RGB to XYZ
-	x := linearR*0.4124564 + linearG*0.3575761 + linearB*0.1804375
-	y := linearR*0.2126729 + linearG*0.7151522 + linearB*0.0721750
-	z := linearR*0.0193339 + linearG*0.1191920 + linearB*0.9503041
+	linearR, linearG, linearB, a := LinearRGBA(c)
+	x, y, z := linearSRGBToXYZ(linearR, linearG, linearB)
 
 	return &XYZ{X: x, Y: y, Z: z, A: a}
 }
 
+// The sRGB matrices of SRGBSpace, derived from the BT.709 primaries, so that
+// InGamut and the RGBA conversions agree with SRGBSpace.
+var (
+	srgbToXYZ = SRGBSpace.(*rgbSpace).rgbToXYZMatrix
+	xyzToSRGB = SRGBSpace.(*rgbSpace).xyzToRGBMatrix
+)
+
+// xyzToLinearSRGB converts XYZ (D65) to linear sRGB without clamping.
+func xyzToLinearSRGB(x, y, z float64) (r, g, b float64) {
+	v := mulMatrix3(xyzToSRGB, x, y, z)
+	return v[0], v[1], v[2]
+}
+
+// linearSRGBToXYZ converts linear sRGB to XYZ (D65) without clamping.
+func linearSRGBToXYZ(r, g, b float64) (x, y, z float64) {
+	v := mulMatrix3(srgbToXYZ, r, g, b)
+	return v[0], v[1], v[2]
+}
+
 // gammaCorrection applies sRGB gamma correction.
+// Negative values are mirrored around zero, as in CSS Color 4, so that
+// out-of-gamut components survive a round trip.
 func gammaCorrection(linear float64) float64 {
+	if linear < 0 {
+		return -gammaCorrection(-linear)
+	}
 	if linear <= 0.0031308 {
 		return 12.92 * linear
 	}
@@ -66,6 +94,9 @@ func gammaCorrection(linear float64) float64 {
 
 // inverseGammaCorrection reverses sRGB gamma correction.
 func inverseGammaCorrection(srgb float64) float64 {
+	if srgb < 0 {
+		return -inverseGammaCorrection(-srgb)
+	}
 	if srgb <= 0.04045 {
 		return srgb / 12.92
 	}